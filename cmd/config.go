@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateStore   string
+	migrateKeyFile string
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the CLI configuration",
+}
+
+var configMigrateCredentialsCmd = &cobra.Command{
+	Use:   "migrate-credentials",
+	Short: "Move stored tokens out of config.yaml into a credential store",
+	Long: `Move the access, refresh and API tokens of all profiles out of
+~/.chrono/config.yaml into a credential store.
+
+Stores:
+  secret-service  the desktop keyring (GNOME Keyring, KWallet) via secret-tool
+  file            ~/.chrono/credentials.enc, encrypted with AES-256-GCM
+  plaintext       config.yaml itself (the previous behavior)
+
+The file store derives its key from CHRONO_CREDENTIALS_PASSPHRASE when set,
+otherwise from a random key file. The key file is kept outside ~/.chrono so
+backups and dotfile sync of that directory do not carry it along; by default
+it is ~/.local/state/chrono/credentials.key ($XDG_STATE_HOME/chrono when set),
+~/Library/Application Support/chrono on macOS and %LocalAppData%\chrono on
+Windows.
+
+Without --store, the Secret Service is used when available, else the file store.
+
+Example:
+  chrono config migrate-credentials
+  CHRONO_CREDENTIALS_PASSPHRASE=... chrono config migrate-credentials --store file`,
+	Args: cobra.NoArgs,
+	RunE: runConfigMigrateCredentials,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configMigrateCredentialsCmd)
+
+	configMigrateCredentialsCmd.Flags().StringVar(&migrateStore, "store", "", "credential store (secret-service, file, plaintext)")
+	configMigrateCredentialsCmd.Flags().StringVar(&migrateKeyFile, "key-file", "", "key file for the file store (default: outside ~/.chrono, see above)")
+}
+
+func runConfigMigrateCredentials(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+
+	store := migrateStore
+	if store == "" {
+		store = config.DefaultCredentialStore()
+	}
+	if migrateKeyFile != "" && store != config.CredentialStoreFile {
+		return fmt.Errorf("--key-file can only be used with the file store")
+	}
+
+	from := cfg.Credentials.Store
+	if from == "" {
+		from = config.CredentialStorePlaintext
+	}
+
+	target := config.CredentialsConfig{Store: store, KeyFile: migrateKeyFile}
+	if err := cfg.MigrateCredentials(target); err != nil {
+		return fmt.Errorf("failed to migrate credentials: %w", err)
+	}
+
+	fmt.Printf("✓ Moved credentials of %d profile(s) from the %s store to the %s store\n", len(cfg.Profiles), from, store)
+	if store == config.CredentialStoreFile {
+		if os.Getenv(config.EnvCredentialsPassphrase) != "" {
+			fmt.Printf("  The key is derived from %s; set it for every chrono command.\n", config.EnvCredentialsPassphrase)
+		} else {
+			fmt.Println("  Keep the key file out of backups and dotfile sync, or use " + config.EnvCredentialsPassphrase + ".")
+		}
+	}
+	return nil
+}
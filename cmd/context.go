@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contextServerURL string
+	contextEditor    string
+	contextUse       bool
+)
+
+// contextCmd represents the context command
+var contextCmd = &cobra.Command{
+	Use:     "context",
+	Aliases: []string{"profile"},
+	Short:   "Manage profiles for multiple platform environments",
+	Long: `Manage named profiles (contexts) for multiple platform environments.
+
+Each profile has its own server URL, credentials and defaults. Commands such as
+login, status and mcp-setup act on the active profile.
+
+The active profile is chosen in this order:
+  1. --profile flag
+  2. CHRONO_PROFILE environment variable
+  3. current context set with 'chrono context use'`,
+}
+
+var contextListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List profiles",
+	Args:    cobra.NoArgs,
+	RunE:    runContextList,
+}
+
+var contextCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show the active profile",
+	Args:  cobra.NoArgs,
+	RunE:  runContextCurrent,
+}
+
+var contextUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Switch the current profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runContextUse,
+}
+
+var contextCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a new profile",
+	Long: `Create a new profile for a platform environment.
+
+Example:
+  chrono context create staging --server https://staging.example.com/api/v1 --use
+  chrono login`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContextCreate,
+}
+
+var contextDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a profile and its stored credentials",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runContextDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(contextCmd)
+	contextCmd.AddCommand(contextListCmd)
+	contextCmd.AddCommand(contextCurrentCmd)
+	contextCmd.AddCommand(contextUseCmd)
+	contextCmd.AddCommand(contextCreateCmd)
+	contextCmd.AddCommand(contextDeleteCmd)
+
+	contextCreateCmd.Flags().StringVar(&contextServerURL, "server", "", "API server URL for this profile")
+	contextCreateCmd.Flags().StringVar(&contextEditor, "editor", "", "default AI editor for mcp-setup (cursor, claude-code, codex, gemini)")
+	contextCreateCmd.Flags().BoolVar(&contextUse, "use", false, "switch to the new profile")
+}
+
+func runContextList(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tSERVER\tUSER")
+	for _, name := range cfg.ProfileNames() {
+		profile := cfg.Profiles[name]
+		if name == cfg.ProfileName() {
+			// Show unsaved overrides (e.g. --api-url) for the active profile
+			profile = &config.Profile{Auth: cfg.Auth, MCP: cfg.MCP}
+		}
+
+		current := ""
+		if name == cfg.ProfileName() {
+			current = "*"
+		}
+
+		user := profile.Auth.Email
+		if user == "" {
+			user = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", current, name, profile.MCP.ServerURL, user)
+	}
+	return w.Flush()
+}
+
+func runContextCurrent(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	fmt.Println(cfg.ProfileName())
+	return nil
+}
+
+func runContextUse(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	name := args[0]
+
+	if err := cfg.SelectProfile(name); err != nil {
+		return err
+	}
+	cfg.CurrentProfile = name
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✓ Switched to profile %q (%s)\n", name, cfg.MCP.ServerURL)
+	return nil
+}
+
+func runContextCreate(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	name := args[0]
+
+	if contextEditor != "" && mapEditorToIndex(contextEditor) < 0 {
+		return fmt.Errorf("unknown editor: %s. Valid options: cursor, claude-code, codex, gemini", contextEditor)
+	}
+
+	if err := cfg.CreateProfile(name, contextServerURL); err != nil {
+		return err
+	}
+	cfg.Profiles[name].Defaults.Editor = contextEditor
+
+	if contextUse {
+		if err := cfg.SelectProfile(name); err != nil {
+			return err
+		}
+		cfg.CurrentProfile = name
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✓ Created profile %q (%s)\n", name, cfg.Profiles[name].MCP.ServerURL)
+	if contextUse {
+		fmt.Printf("✓ Switched to profile %q\n", name)
+		fmt.Println()
+		fmt.Println("Next Steps:")
+		fmt.Println("  chrono login         # Authenticate with this environment")
+	} else {
+		fmt.Printf("  Use 'chrono context use %s' to switch to it.\n", name)
+	}
+	return nil
+}
+
+func runContextDelete(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	name := args[0]
+
+	if err := cfg.DeleteProfile(name); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✓ Deleted profile %q\n", name)
+	return nil
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/ChronoAIProject/chrono-cli/pkg/config"
+	"github.com/ChronoAIProject/chrono-cli/pkg/gitinfo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deployPipeline    string
+	deployBranch      string
+	deployCommit      string
+	deployNoWait      bool
+	deployWaitTimeout time.Duration
+
+	deploySkipGitCheck bool
+)
+
+// deployCmd represents the deploy command
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Deploy the current repository and branch",
+	Long: `Trigger a run of the pipeline of the current repository and branch and
+follow it until the application is deployed.
+
+The pipeline is found by the origin remote and the current branch; create it
+first with 'chrono pipelines create'. The platform builds the branch as
+pushed to origin, so the deploy stops when the branch has unpushed commits or
+HEAD is detached; 'chrono git check' shows the details. With --branch or
+--pipeline, the branch deployed is checked in the local clone; the check is
+skipped, with a note, outside a clone of the pipeline's repository. The progress of each
+stage is shown while the run is in progress, and the application URLs once
+it succeeds.
+
+Exit codes: 0 when the deployment succeeded, 11 when the run failed or was
+cancelled, 9 when --wait-timeout elapsed first. The run keeps going on the
+platform when the CLI stops waiting.
+
+Examples:
+  chrono deploy
+  chrono deploy --wait-timeout 10m
+  chrono deploy --pipeline shop-main --no-wait`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runDeploy,
+}
+
+func init() {
+	rootCmd.AddCommand(deployCmd)
+	deployCmd.Flags().StringVar(&deployPipeline, "pipeline", "", "pipeline name or ID (default: the pipeline of the current repository and branch)")
+	deployCmd.Flags().StringVar(&deployBranch, "branch", "", "branch whose pipeline to run (default: the current branch)")
+	deployCmd.Flags().StringVar(&deployCommit, "commit", "", "commit to deploy (default: the head of the branch)")
+	deployCmd.Flags().BoolVar(&deployNoWait, "no-wait", false, "return once the run is started")
+	deployCmd.Flags().DurationVar(&deployWaitTimeout, "wait-timeout", 20*time.Minute, "how long to wait for the run to finish")
+	deployCmd.Flags().BoolVar(&deploySkipGitCheck, "skip-git-check", false, "deploy even if the branch has unpushed commits")
+}
+
+func runDeploy(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+	if deployWaitTimeout <= 0 {
+		return fmt.Errorf("--wait-timeout must be positive")
+	}
+
+	client := GetAPIClient(cfg)
+	p, err := deployTarget(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.TriggerRun(ctx, p.ID, &api.TriggerRunRequest{CommitSHA: deployCommit})
+	if err != nil {
+		return fmt.Errorf("failed to trigger run: %w", err)
+	}
+	fmt.Printf("Deploying %s (%s) to %s: run #%d (%s)\n", p.Repository, p.Branch, p.AppName, run.Number, run.ID)
+	if deployNoWait {
+		fmt.Printf("Follow it with: chrono runs watch %s\n", run.ID)
+		return nil
+	}
+	fmt.Println()
+
+	waitCtx, cancel := context.WithTimeout(ctx, deployWaitTimeout)
+	defer cancel()
+	run, err = watchRun(waitCtx, client, run)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return withExitCode(exitCodeTimeout, "run %s did not finish within %s; it keeps running on the platform. Follow it with 'chrono runs watch %s'", run.ID, deployWaitTimeout, run.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if err := checkRunResult(ctx, client, run); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Deployed %s in %s\n", p.AppName, formatDuration(runDuration(run)))
+	printPipelineURLs(p)
+	return nil
+}
+
+// gitPreflight checks that the checked-out branch is what the platform
+// deploys, printing the problems found
+func gitPreflight(status *gitinfo.Status) error {
+	if deploySkipGitCheck {
+		return nil
+	}
+	problems := gitinfo.Check(status)
+	if len(problems) == 0 {
+		return nil
+	}
+	printGitProblems(problems)
+	if gitinfo.HasErrors(problems) {
+		return fmt.Errorf("git preflight failed; resolve the problems above or skip the check with --skip-git-check")
+	}
+	fmt.Println()
+	return nil
+}
+
+// branchPreflight runs the git preflight for the branch a pipeline deploys
+// when the working directory is a clone of its repository. Otherwise it
+// notes that the check was skipped.
+func branchPreflight(repository, branch string) error {
+	if deploySkipGitCheck {
+		return nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	status, err := gitinfo.InspectBranch(wd, branch)
+	switch {
+	case errors.Is(err, gitinfo.ErrUnknownBranch):
+		fmt.Printf("Note: skipped the git check; branch %s has not been fetched from %s\n\n", branch, gitinfo.RemoteName)
+		return nil
+	case err != nil:
+		fmt.Printf("Note: skipped the git check; cannot inspect %s: %v\n\n", wd, err)
+		return nil
+	case !strings.EqualFold(status.Repository(), repository):
+		fmt.Printf("Note: skipped the git check; %s is not a clone of %s\n\n", status.Root, repository)
+		return nil
+	}
+	return gitPreflight(status)
+}
+
+// deployTarget returns the pipeline named by --pipeline, or the pipeline of
+// the current repository and branch
+func deployTarget(ctx context.Context, client *api.Client) (*api.Pipeline, error) {
+	if deployPipeline != "" {
+		p, err := resolvePipeline(ctx, client, deployPipeline)
+		if err != nil {
+			return nil, err
+		}
+		if err := branchPreflight(p.Repository, p.Branch); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+
+	status, err := inspectRepository()
+	if err != nil {
+		return nil, err
+	}
+	repository, branch := status.Repository(), status.Branch
+	if deployBranch != "" && deployBranch != branch {
+		branch = deployBranch
+		if err := branchPreflight(repository, branch); err != nil {
+			return nil, err
+		}
+	} else if err := gitPreflight(status); err != nil {
+		return nil, err
+	}
+	if repository == "" {
+		return nil, fmt.Errorf("cannot determine the repository from the origin remote; name the pipeline with --pipeline")
+	}
+	if branch == "" {
+		return nil, fmt.Errorf("cannot determine the current branch; set it with --branch")
+	}
+
+	filter := api.PipelineFilter{Repository: repository, Branch: branch}
+	if link, _, err := config.FindProjectLink("."); err == nil {
+		filter.ProjectID = link.ProjectID
+	}
+	resp, err := client.ListPipelines(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+
+	switch len(resp.Pipelines) {
+	case 0:
+		return nil, withExitCode(exitCodeNotFound, "no pipeline for %s on branch %s. Create one with 'chrono pipelines create'", repository, branch)
+	case 1:
+		return resp.Pipelines[0], nil
+	default:
+		return nil, fmt.Errorf("%d pipelines deploy %s on branch %s; choose one with --pipeline", len(resp.Pipelines), repository, branch)
+	}
+}
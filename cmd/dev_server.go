@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/platformtest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	devServerAddr            string
+	devServerAutoApprove     bool
+	devServerStageDuration   time.Duration
+	devServerLatency         time.Duration
+	devServerFailStage       string
+	devServerFaults          []string
+	devServerServiceAccounts []string
+	devServerMinCLI          string
+	devServerRecommendedCLI  string
+)
+
+// devServerCmd represents the dev-server command
+var devServerCmd = &cobra.Command{
+	Use:   "dev-server",
+	Short: "Run an in-memory Developer Platform for tests and demos",
+	Long: `Run a fake Developer Platform that keeps all state in memory.
+
+It implements the device flow, API tokens, teams, skills, MCP info and the
+project, pipeline, run and deployment lifecycle, so the CLI and scripts can
+be exercised without touching a real platform. State is lost on exit.
+
+Device logins are approved by opening the printed verification URL, or
+immediately with --auto-approve.
+
+Faults have the form "[METHOD ]PATH STATUS[xTIMES][+DELAY]", where PATH is
+relative to /api/v1 and STATUS 0 only adds the delay.
+
+Example:
+  chrono dev-server --auto-approve
+  chrono --api-url http://localhost:8080/api/v1 login
+
+  chrono dev-server --fail-stage build --fault "POST /pipelines 503x2" --fault "/runs 0+2s"`,
+	Args: cobra.NoArgs,
+	RunE: runDevServer,
+}
+
+func init() {
+	rootCmd.AddCommand(devServerCmd)
+
+	devServerCmd.Flags().StringVar(&devServerAddr, "addr", "localhost:8080", "address to listen on")
+	devServerCmd.Flags().BoolVar(&devServerAutoApprove, "auto-approve", false, "approve device logins without visiting the verification URL")
+	devServerCmd.Flags().DurationVar(&devServerStageDuration, "stage-duration", platformtest.DefaultStageDuration, "how long each stage of a pipeline run takes")
+	devServerCmd.Flags().DurationVar(&devServerLatency, "latency", 0, "delay added to every request")
+	devServerCmd.Flags().StringVar(&devServerFailStage, "fail-stage", "", "make pipeline runs fail at this stage (clone, build or deploy)")
+	devServerCmd.Flags().StringArrayVar(&devServerFaults, "fault", nil, "inject a fault, e.g. \"GET /auth/me 503x2\" (repeatable)")
+	devServerCmd.Flags().StringVar(&devServerMinCLI, "min-cli-version", "", "reject CLIs older than this version with 426 Upgrade Required")
+	devServerCmd.Flags().StringVar(&devServerRecommendedCLI, "recommended-cli-version", "", "advertise this CLI version as recommended")
+	devServerCmd.Flags().StringArrayVar(&devServerServiceAccounts, "service-account", nil, "accept service account credentials as <client-id>:<secret> (repeatable)")
+}
+
+func runDevServer(cmd *cobra.Command, args []string) error {
+	server := platformtest.New()
+	server.AutoApprove = devServerAutoApprove
+	server.StageDuration = devServerStageDuration
+	server.Latency = devServerLatency
+	server.MinCLIVersion = devServerMinCLI
+	server.RecommendedCLIVersion = devServerRecommendedCLI
+
+	if devServerFailStage != "" {
+		if !slices.Contains(platformtest.RunStages, devServerFailStage) {
+			return fmt.Errorf("invalid --fail-stage %q: must be one of %s", devServerFailStage, strings.Join(platformtest.RunStages, ", "))
+		}
+		server.FailStage = devServerFailStage
+	}
+	for _, spec := range devServerFaults {
+		fault, err := platformtest.ParseFault(spec)
+		if err != nil {
+			return err
+		}
+		server.AddFault(fault)
+	}
+	for _, account := range devServerServiceAccounts {
+		clientID, secret, ok := strings.Cut(account, ":")
+		if !ok || clientID == "" || secret == "" {
+			return fmt.Errorf("invalid --service-account %q: expected <client-id>:<secret>", account)
+		}
+		server.AddServiceAccount(clientID, secret)
+	}
+
+	listener, err := net.Listen("tcp", devServerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", devServerAddr, err)
+	}
+	baseURL := "http://" + listener.Addr().String()
+
+	fmt.Println("Developer Platform (in-memory) is running")
+	fmt.Println()
+	fmt.Printf("  API URL:     %s%s\n", baseURL, platformtest.APIPrefix)
+	fmt.Printf("  Activation:  %s/activate\n", baseURL)
+	fmt.Printf("  User:        %s (team %s)\n", platformtest.DefaultUserEmail, platformtest.DefaultTeamID)
+	fmt.Println()
+	fmt.Println("Use it from another terminal:")
+	fmt.Printf("  export CHRONO_API_URL=%s%s\n", baseURL, platformtest.APIPrefix)
+	fmt.Println("  chrono login")
+	fmt.Println()
+	fmt.Println("Press Ctrl-C to stop. All state is lost on exit.")
+
+	httpServer := &http.Server{Handler: server, ReadHeaderTimeout: 10 * time.Second}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("dev server failed: %w", err)
+	case <-cmd.Context().Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to stop dev server: %w", err)
+	}
+	fmt.Println("\nStopped")
+	return nil
+}
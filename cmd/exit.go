@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+)
+
+// Exit codes returned to scripts. They are stable; add new codes rather than
+// changing existing ones.
+const (
+	exitCodeError       = 1 // generic failure
+	exitCodeNoSession   = 3 // not logged in, or the server rejected the session (401)
+	exitCodeForbidden   = 4 // the server denied access (403)
+	exitCodeNotFound    = 5 // the resource does not exist (404)
+	exitCodeConflict    = 6 // the request conflicts with the current state (409)
+	exitCodeRateLimited = 7 // too many requests (429)
+	exitCodeServerError = 8 // the platform failed (5xx)
+	exitCodeTimeout     = 9 // --timeout elapsed
+
+	exitCodeUnsupportedVersion = 10 // the platform requires a newer CLI
+	exitCodeRunFailed          = 11 // a pipeline run failed or was cancelled
+
+	exitCodeInterrupted = 130 // cancelled with Ctrl-C, as for SIGINT in shells
+)
+
+// exitError is an error that terminates the CLI with a specific exit code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode wraps err so Execute exits with the given code
+func withExitCode(code int, format string, args ...any) error {
+	return &exitError{code: code, err: fmt.Errorf(format, args...)}
+}
+
+// exitCode returns the process exit code for an error returned by a command
+func exitCode(err error) int {
+	var exitErr *exitError
+	var apiErr *api.Error
+	var versionErr *api.UnsupportedVersionError
+	switch {
+	case errors.As(err, &exitErr):
+		return exitErr.code
+	case errors.As(err, &versionErr):
+		return exitCodeUnsupportedVersion
+	case errors.Is(err, context.Canceled):
+		return exitCodeInterrupted
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitCodeTimeout
+	case errors.As(err, &apiErr):
+		switch {
+		case api.IsUnauthorized(err):
+			return exitCodeNoSession
+		case api.IsForbidden(err):
+			return exitCodeForbidden
+		case api.IsNotFound(err):
+			return exitCodeNotFound
+		case api.IsConflict(err):
+			return exitCodeConflict
+		case api.IsRateLimited(err):
+			return exitCodeRateLimited
+		case api.IsServerError(err):
+			return exitCodeServerError
+		}
+	}
+	return exitCodeError
+}
+
+// errorHint returns advice on how to resolve an error, or an empty string
+func errorHint(err error) string {
+	var versionErr *api.UnsupportedVersionError
+	switch {
+	case errors.As(err, &versionErr):
+		return "Upgrade the CLI: " + upgradeCommand
+	case api.IsUnauthorized(err):
+		return "Your session is no longer valid. Run 'chrono login' to re-authenticate."
+	case api.IsForbidden(err):
+		return "You do not have access. Check the active team with 'chrono team show' or ask a team owner for access."
+	case api.IsNotFound(err):
+		return "Check the name or ID. The resource may belong to another team ('chrono team list')."
+	case api.IsConflict(err):
+		return "The resource was changed or already exists. Fetch its current state and try again."
+	case api.IsRateLimited(err):
+		return "The platform is rate limiting requests. Wait a moment and try again."
+	case api.IsServerError(err):
+		return "The platform failed to handle the request. Try again later, or report it with the request ID."
+	case errors.Is(err, api.ErrUnmatchedRequest):
+		return "The request differs from the recording. Record it again with CHRONO_RECORD."
+	}
+	return ""
+}
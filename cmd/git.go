@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/gitinfo"
+	"github.com/spf13/cobra"
+)
+
+var gitCheckJSON bool
+
+// gitCmd represents the git command
+var gitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Inspect the Git repository being deployed",
+}
+
+var gitCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check that the current branch can be deployed",
+	Long: `Check the Git repository in the current directory before deploying.
+
+The platform builds the branch as pushed to origin, so the check reports
+a missing or unrecognized origin remote, a detached HEAD, and commits that
+have not been pushed. Uncommitted changes and a branch behind origin are
+reported as warnings. 'chrono deploy' runs the same check first.
+
+Comparisons with origin use the remote branches as of the last 'git fetch'.
+The command exits with code 1 when the branch cannot be deployed.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runGitCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(gitCmd)
+	gitCmd.AddCommand(gitCheckCmd)
+	gitCheckCmd.Flags().BoolVar(&gitCheckJSON, "json", false, "Output as JSON")
+}
+
+// gitCheckOutput is the JSON form of the git check command
+type gitCheckOutput struct {
+	*gitinfo.Status
+	Repository string            `json:"repository,omitempty"`
+	Problems   []gitinfo.Problem `json:"problems"`
+	Ready      bool              `json:"ready"`
+}
+
+func runGitCheck(cmd *cobra.Command, args []string) error {
+	status, err := inspectRepository()
+	if err != nil {
+		return err
+	}
+	problems := gitinfo.Check(status)
+	ready := !gitinfo.HasErrors(problems)
+
+	if gitCheckJSON {
+		out := gitCheckOutput{Status: status, Repository: status.Repository(), Problems: problems, Ready: ready}
+		if out.Problems == nil {
+			out.Problems = []gitinfo.Problem{}
+		}
+		if err := printJSON(out); err != nil {
+			return err
+		}
+	} else {
+		printGitStatus(status)
+		fmt.Println()
+		printGitProblems(problems)
+		if ready {
+			fmt.Println("✓ Ready to deploy")
+		}
+	}
+
+	if !ready {
+		return fmt.Errorf("branch cannot be deployed; resolve the problems above")
+	}
+	return nil
+}
+
+// inspectRepository inspects the repository in the current directory
+func inspectRepository() (*gitinfo.Status, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	status, err := gitinfo.Inspect(wd)
+	if errors.Is(err, gitinfo.ErrNotRepository) {
+		return nil, fmt.Errorf("%s is %w. Run the command inside the repository you deploy", wd, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect repository: %w", err)
+	}
+	return status, nil
+}
+
+// printGitStatus prints the remote, branch and state of a repository
+func printGitStatus(s *gitinfo.Status) {
+	if s.Remote != nil {
+		fmt.Printf("Repository:   %s (%s)\n", s.Repository(), s.Remote.Host)
+	} else {
+		fmt.Printf("Repository:   %s\n", valueOrDash(s.RemoteURL))
+	}
+	if s.Detached {
+		fmt.Printf("Branch:       - (detached HEAD)\n")
+	} else {
+		fmt.Printf("Branch:       %s\n", s.Branch)
+	}
+	fmt.Printf("Commit:       %s\n", valueOrDash(s.ShortHead()))
+	if s.RemoteBranch != "" {
+		fmt.Printf("Remote:       %s (%d ahead, %d behind)\n", s.RemoteBranch, s.Ahead, s.Behind)
+	} else if !s.Detached && s.Head != "" {
+		fmt.Printf("Remote:       not pushed\n")
+	}
+	if s.Dirty() {
+		fmt.Printf("Working tree: %d uncommitted changes\n", len(s.Changes))
+	} else {
+		fmt.Printf("Working tree: clean\n")
+	}
+}
+
+// printGitProblems prints problems with the command or action resolving each
+func printGitProblems(problems []gitinfo.Problem) {
+	for _, p := range problems {
+		icon := "✗"
+		if p.Severity == gitinfo.SeverityWarning {
+			icon = "⚠"
+		}
+		fmt.Printf("%s %s\n", icon, p.Message)
+		fmt.Printf("    → %s\n", p.Remedy)
+	}
+}
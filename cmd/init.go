@@ -202,6 +202,8 @@ func installAllSkills(serverURL string) ([]string, error) {
 		fmt.Printf("Installing skills to %d location(s)\n", len(installLocations))
 	}
 
+	client := httpClient(&GetConfig().HTTP)
+
 	// GitHub configuration
 	githubRepo := "ChronoAIProject/chrono-cli"
 	githubRef := "main"
@@ -218,7 +220,7 @@ func installAllSkills(serverURL string) ([]string, error) {
 		fmt.Printf("  Downloading %s...", skillName)
 
 		// Try to fetch from GitHub first
-		resp, err := http.Get(githubURL)
+		resp, err := client.Get(githubURL)
 		if err != nil || resp.StatusCode != http.StatusOK {
 			// Fallback: try to copy from local skills directory (for development)
 			if resp != nil {
@@ -234,7 +236,7 @@ func installAllSkills(serverURL string) ([]string, error) {
 			} else {
 				// Try from backend API
 				apiURL := serverURL + "/skills/" + skillName
-				if apiResp, apiErr := http.Get(apiURL); apiErr == nil {
+				if apiResp, apiErr := client.Get(apiURL); apiErr == nil {
 					defer apiResp.Body.Close()
 					if apiResp.StatusCode == http.StatusOK {
 						if apiContent, readErr := io.ReadAll(apiResp.Body); readErr == nil {
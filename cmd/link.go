@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/ChronoAIProject/chrono-cli/pkg/config"
+	"github.com/ChronoAIProject/chrono-cli/pkg/gitinfo"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// linkCmd represents the link command
+var linkCmd = &cobra.Command{
+	Use:   "link [project]",
+	Short: "Link this repository to a platform project",
+	Long: `Record the platform project this repository deploys to.
+
+The project ID is written to .chrono/project.yaml, next to the metadata saved
+by 'chrono detect --save', so pipeline and deploy commands know which project
+to use. Without an argument, pick the project interactively.
+
+Examples:
+  chrono link
+  chrono link shop
+  chrono projects create shop --link`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runLink,
+}
+
+func init() {
+	rootCmd.AddCommand(linkCmd)
+}
+
+func runLink(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	var project *api.Project
+	var err error
+	if len(args) > 0 {
+		project, err = resolveProject(ctx, client, args[0])
+	} else {
+		project, err = selectProject(cmd, client)
+	}
+	if err != nil {
+		return err
+	}
+
+	return linkProject(cfg.MCP.ServerURL, project, false)
+}
+
+// selectProject asks the user to pick one of the team's projects
+func selectProject(cmd *cobra.Command, client *api.Client) (*api.Project, error) {
+	resp, err := client.ListProjects(cmd.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	if len(resp.Projects) == 0 {
+		return nil, fmt.Errorf("no projects found. Create one with 'chrono projects create <name> --link'")
+	}
+
+	items := make([]string, len(resp.Projects))
+	for i, project := range resp.Projects {
+		items[i] = fmt.Sprintf("%s (%s)", project.Name, project.ID)
+	}
+	prompt := promptui.Select{
+		Label: "Which project does this repository belong to?",
+		Items: items,
+	}
+	idx, _, err := prompt.Run()
+	if err != nil {
+		return nil, fmt.Errorf("prompt failed: %w", err)
+	}
+	return resp.Projects[idx], nil
+}
+
+// linkProject records the project in .chrono/project.yaml of the current
+// repository. Unless quiet, it reports where the link was written.
+func linkProject(serverURL string, project *api.Project, quiet bool) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	dir := projectRoot(wd)
+
+	link := &config.ProjectLink{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		TeamID:      project.TeamID,
+		Server:      serverURL,
+		LinkedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+	if err := config.SaveProjectLink(dir, link); err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("✓ Linked %s to project %q (%s)\n", dir, project.Name, project.ID)
+	}
+	return nil
+}
+
+// projectRoot returns the directory holding the repository's .chrono
+// directory: the nearest one with an existing link or .chrono directory
+// below the home directory, else the top level of the Git repository, or wd
+// outside a repository
+func projectRoot(wd string) string {
+	if _, dir, err := config.FindProjectLink(wd); err == nil {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	for dir := wd; dir != home; dir = filepath.Dir(dir) {
+		if stat, err := os.Stat(filepath.Join(dir, ".chrono")); err == nil && stat.IsDir() {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	if root, err := gitinfo.Root(wd); err == nil {
+		return root
+	}
+	return wd
+}
+
+// linkedProject returns the project the current repository is linked to
+func linkedProject() (*config.ProjectLink, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	link, _, err := config.FindProjectLink(wd)
+	if errors.Is(err, config.ErrNotLinked) {
+		return nil, fmt.Errorf("this repository is %w", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
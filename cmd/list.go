@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// defaultListLimit is the number of items list commands show without --limit or --all
+const defaultListLimit = 50
+
+// tableFlushRows is the number of rows aligned and printed together when
+// streaming a table
+const tableFlushRows = api.DefaultPageSize
+
+// listFlags are the paging flags shared by list commands
+type listFlags struct {
+	limit int
+	all   bool
+}
+
+// register adds --limit and --all to a list command
+func (f *listFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&f.limit, "limit", defaultListLimit, "maximum number of items to list")
+	cmd.Flags().BoolVar(&f.all, "all", false, "list all items, ignoring --limit")
+}
+
+// options returns the iterator options selected by the flags
+func (f *listFlags) options() (api.ListOptions, error) {
+	if f.all {
+		return api.ListOptions{}, nil
+	}
+	if f.limit < 1 {
+		return api.ListOptions{}, fmt.Errorf("--limit must be at least 1")
+	}
+	return api.ListOptions{Limit: f.limit}, nil
+}
+
+// printTruncated tells the user when the limit hid part of a list
+func (f *listFlags) printTruncated(shown, total int, noun string) {
+	if f.all || shown < f.limit {
+		return
+	}
+	if total > shown {
+		fmt.Fprintf(os.Stderr, "Showing %d of %d %s. Use --all to list all of them.\n", shown, total, noun)
+	} else if total == 0 {
+		fmt.Fprintf(os.Stderr, "Showing the first %d %s. Use --all to list all of them.\n", shown, noun)
+	}
+}
+
+// printList prints the items of it as they are fetched, as a JSON array or
+// as a table with one row per item, so only one page is held in memory. It
+// returns the number of items printed.
+func printList[T any](ctx context.Context, it *api.Iterator[T], asJSON bool, header []string, row func(T) []string) (int, error) {
+	if asJSON {
+		return printJSONList(ctx, it)
+	}
+
+	table := newTableWriter(header...)
+	count := 0
+	for it.Next(ctx) {
+		table.Row(row(it.Item())...)
+		count++
+	}
+	table.Flush()
+	return count, it.Err()
+}
+
+// printJSONList prints the items of it as an indented JSON array, in the
+// same format as printJSON
+func printJSONList[T any](ctx context.Context, it *api.Iterator[T]) (int, error) {
+	count := 0
+	for it.Next(ctx) {
+		data, err := json.MarshalIndent(it.Item(), "  ", "  ")
+		if err != nil {
+			return count, err
+		}
+		if count == 0 {
+			fmt.Print("[\n  ")
+		} else {
+			fmt.Print(",\n  ")
+		}
+		os.Stdout.Write(data)
+		count++
+	}
+	if count == 0 {
+		fmt.Println("[]")
+	} else {
+		fmt.Println("\n]")
+	}
+	return count, it.Err()
+}
+
+// tableWriter prints a table row by row. The header is printed with the
+// first row, and columns are aligned within blocks of tableFlushRows rows.
+type tableWriter struct {
+	w      *tabwriter.Writer
+	header []string
+	rows   int
+}
+
+// newTableWriter returns a table writer for stdout
+func newTableWriter(header ...string) *tableWriter {
+	return &tableWriter{
+		w:      tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0),
+		header: header,
+	}
+}
+
+// Row adds a row to the table
+func (t *tableWriter) Row(cols ...string) {
+	if t.rows == 0 && len(t.header) > 0 {
+		fmt.Fprintln(t.w, strings.Join(t.header, "\t"))
+	}
+	fmt.Fprintln(t.w, strings.Join(cols, "\t"))
+	t.rows++
+	if t.rows%tableFlushRows == 0 {
+		t.w.Flush()
+	}
+}
+
+// Flush prints the buffered rows
+func (t *tableWriter) Flush() {
+	t.w.Flush()
+}
@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
-	"os/exec"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/ChronoAIProject/chrono-cli/pkg/auth"
+	"github.com/ChronoAIProject/chrono-cli/pkg/config"
 	"github.com/spf13/cobra"
 )
 
@@ -15,37 +22,218 @@ var loginCmd = &cobra.Command{
 	Short: "Authenticate via Keycloak device flow",
 	Long: `Authenticate with the Developer Platform using Keycloak device flow.
 
-This will open your browser or provide a code to enter for authentication.`,
+This will open your browser or provide a code to enter for authentication.
+
+With --web, the browser is redirected back to a temporary local server on
+127.0.0.1 so no code has to be copied (authorization code flow with PKCE).
+If no browser can be opened, the device flow is used instead.
+
+Non-interactive login (CI):
+  echo "$TOKEN" | chrono login --with-token
+  CHRONO_CLIENT_SECRET=... chrono login --client-id <service-account-id>
+
+Alternatively, set CHRONO_TOKEN (or CHRONO_CLIENT_ID and CHRONO_CLIENT_SECRET)
+to use credentials without logging in. CHRONO_API_URL sets the server URL.`,
 	RunE: runLogin,
 }
 
-var openBrowser bool
+var (
+	openBrowser    bool
+	loginWeb       bool
+	loginWithToken bool
+	loginClientID  string
+)
+
+// webLoginTimeout limits how long the browser login waits for the redirect
+const webLoginTimeout = 5 * time.Minute
 
 func init() {
 	rootCmd.AddCommand(loginCmd)
 	loginCmd.Flags().BoolVar(&openBrowser, "browser", true, "automatically open browser for authentication")
+	loginCmd.Flags().BoolVar(&loginWeb, "web", false, "authenticate in the browser via a local callback instead of entering a code")
+	loginCmd.Flags().BoolVar(&loginWithToken, "with-token", false, "read an API token from standard input")
+	loginCmd.Flags().StringVar(&loginClientID, "client-id", "", "service account client ID (secret from CHRONO_CLIENT_SECRET or standard input)")
+	loginCmd.MarkFlagsMutuallyExclusive("web", "with-token", "client-id")
 }
 
 func runLogin(cmd *cobra.Command, args []string) error {
 	cfg := GetConfig()
 
+	if cfg.HasEnvironmentCredentials() {
+		return fmt.Errorf("credentials are provided by %s; unset it to log in", cfg.CredentialSource())
+	}
+
+	// Non-interactive logins replace any existing session
+	if loginWithToken {
+		return loginWithAPIToken(cmd, cfg)
+	}
+	if loginClientID != "" {
+		return loginWithServiceAccount(cmd, cfg)
+	}
+
 	// Check if already logged in
-	if cfg.IsLoggedIn() && !cfg.IsTokenExpired() {
+	if cfg.HasValidSession() {
 		fmt.Printf("Already logged in as %s\n", cfg.Auth.Email)
 		fmt.Println("Use 'chrono logout' to logout first if you want to re-authenticate.")
 		return nil
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.MCP.ServerURL)
+	client := newAPIClient(cfg.MCP.ServerURL, &cfg.HTTP)
+
+	if loginWeb {
+		loginResp, err := loginWithBrowser(cmd, client)
+		if err == nil {
+			return completeLogin(cfg, loginResp, config.AuthMethodBrowser)
+		}
+		if !errors.Is(err, auth.ErrBrowserUnavailable) {
+			return err
+		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "Warning: Could not open a browser, falling back to device flow.")
+		fmt.Println()
+	}
+
+	loginResp, err := loginWithDeviceFlow(cmd, client)
+	if err != nil {
+		return err
+	}
+	return completeLogin(cfg, loginResp, config.AuthMethodDevice)
+}
+
+// loginWithAPIToken validates an API token read from stdin and stores it
+func loginWithAPIToken(cmd *cobra.Command, cfg *config.Config) error {
+	token, err := readSecret(cmd, "Paste your API token: ")
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return fmt.Errorf("no token provided on standard input")
+	}
+
+	client := newAPIClient(cfg.MCP.ServerURL, &cfg.HTTP)
+	client.SetAuthToken(token)
+
+	user, err := client.GetCurrentUser(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("token validation failed: %w", err)
+	}
+
+	cfg.Auth = config.AuthConfig{
+		AccessToken: token,
+		UserID:      user.ID,
+		Email:       user.Email,
+		Method:      config.AuthMethodToken,
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+
+	fmt.Println("✓ API token validated and saved")
+	fmt.Printf("  Logged in as: %s\n", user.Email)
+	return nil
+}
+
+// loginWithServiceAccount obtains a token with the client credentials grant
+func loginWithServiceAccount(cmd *cobra.Command, cfg *config.Config) error {
+	secret := os.Getenv(config.EnvClientSecret)
+	if secret == "" {
+		var err error
+		if secret, err = readSecret(cmd, "Client secret: "); err != nil {
+			return err
+		}
+	}
+	if secret == "" {
+		return fmt.Errorf("no client secret provided. Set %s or pass it on standard input", config.EnvClientSecret)
+	}
+
+	client := newAPIClient(cfg.MCP.ServerURL, &cfg.HTTP)
+	loginResp, err := client.ClientCredentialsLogin(cmd.Context(), loginClientID, secret)
+	if err != nil {
+		return fmt.Errorf("service account login failed: %w", err)
+	}
+
+	cfg.Auth = config.AuthConfig{
+		AccessToken:  loginResp.AccessToken,
+		TokenExpiry:  time.Now().Add(time.Duration(loginResp.ExpiresIn) * time.Second),
+		UserID:       loginResp.User.ID,
+		Email:        loginResp.User.Email,
+		Method:       config.AuthMethodServiceAccount,
+		ClientID:     loginClientID,
+		ClientSecret: secret,
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+
+	fmt.Println("✓ Service account authenticated")
+	fmt.Printf("  Client ID: %s\n", loginClientID)
+	return nil
+}
+
+// readSecret reads a single secret value from stdin, prompting on a terminal
+func readSecret(cmd *cobra.Command, prompt string) (string, error) {
+	in := cmd.InOrStdin()
+	if f, ok := in.(*os.File); ok && isTerminal(f) {
+		fmt.Fprint(cmd.ErrOrStderr(), prompt)
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read from standard input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// loginWithBrowser authenticates using the authorization code flow with PKCE
+func loginWithBrowser(cmd *cobra.Command, client *api.Client) (*api.LoginResponse, error) {
+	fmt.Println("Opening browser for authentication...")
+	fmt.Println("Waiting for you to finish signing in...")
+	fmt.Println()
+
+	flow := &auth.LoopbackFlow{
+		AuthorizeURL: client.AuthorizeURL(),
+		Timeout:      webLoginTimeout,
+	}
+
+	code, err := flow.Authorize(cmd.Context())
+	if err != nil {
+		if errors.Is(err, auth.ErrBrowserUnavailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("browser authentication failed: %w", err)
+	}
+
+	loginResp, err := client.ExchangeAuthorizationCode(cmd.Context(), &api.AuthorizationCodeRequest{
+		Code:         code.Code,
+		CodeVerifier: code.CodeVerifier,
+		RedirectURI:  code.RedirectURI,
+		ClientID:     code.ClientID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	return loginResp, nil
+}
+
+// loginWithDeviceFlow authenticates using the Keycloak device flow
+func loginWithDeviceFlow(cmd *cobra.Command, client *api.Client) (*api.LoginResponse, error) {
+	// Ctrl-C cancels the command context, which stops waiting cleanly
+	ctx := cmd.Context()
+
+	flow := &auth.DeviceFlow{
+		Client: client,
+		OnPending: func() {
+			fmt.Print(".")
+		},
+	}
 
 	// Start device flow
 	fmt.Println("Initiating authentication...")
 	fmt.Println()
 
-	startResp, err := client.StartDeviceFlow()
+	startResp, err := flow.Start(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to start device flow: %w", err)
+		return nil, err
 	}
 
 	// Display user code and verification URL
@@ -65,8 +253,7 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	if openBrowser {
 		fmt.Println("Opening browser...")
 		// Try to open the browser with the complete verification URI
-		browserCmd := exec.Command("open", startResp.VerificationURIComplete)
-		if err := browserCmd.Start(); err != nil {
+		if err := auth.OpenBrowser(startResp.VerificationURIComplete); err != nil {
 			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: Could not open browser automatically: %v\n", err)
 		}
 	} else {
@@ -75,69 +262,56 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Poll for completion
-	fmt.Println("Waiting for authentication...")
-	pollInterval := time.Duration(startResp.Interval) * time.Second
-	if pollInterval < 5*time.Second {
-		pollInterval = 5 * time.Second
-	}
-
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
-	timeout := time.After(time.Duration(startResp.ExpiresIn) * time.Second)
-
-	for {
-		select {
-		case <-timeout:
-			return fmt.Errorf("authentication timed out. Please try again.")
-		case <-ticker.C:
-			pollResp, err := client.PollDeviceFlow(startResp.DeviceCode)
-			if err != nil {
-				// Check if it's still pending
-				if pollResp != nil && pollResp.Status == "authorization_pending" {
-					fmt.Print(".")
-					continue
-				}
-				return fmt.Errorf("failed to poll device flow: %w", err)
-			}
-
-			// Check status
-			if pollResp.Status == "authorization_pending" || pollResp.Status == "slow_down" {
-				fmt.Print(".")
-				if pollResp.Status == "slow_down" {
-					ticker.Reset(pollInterval + 5*time.Second)
-				}
-				continue
-			}
+	fmt.Println("Waiting for authentication... (press Ctrl-C to cancel)")
+	loginResp, err := flow.Wait(ctx, startResp)
+	fmt.Println()
 
-			// Success!
-			fmt.Println()
+	switch {
+	case err == nil:
+		return loginResp, nil
+	case errors.Is(err, auth.ErrAccessDenied):
+		return nil, fmt.Errorf("authentication was denied in the browser. Run 'chrono login' to try again")
+	case errors.Is(err, auth.ErrExpiredToken):
+		return nil, fmt.Errorf("the code expired before authentication completed. Run 'chrono login' to get a new code")
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, err
+	case errors.Is(err, auth.ErrCancelled):
+		return nil, withExitCode(exitCodeInterrupted, "authentication cancelled")
+	default:
+		return nil, err
+	}
+}
 
-			// Save credentials to config
-			cfg.Auth.AccessToken = pollResp.AccessToken
-			cfg.Auth.TokenExpiry = time.Now().Add(time.Duration(pollResp.ExpiresIn) * time.Second)
-			cfg.Auth.UserID = pollResp.User.ID
-			cfg.Auth.Email = pollResp.User.Email
+// completeLogin saves the credentials and shows the success message
+func completeLogin(cfg *config.Config, loginResp *api.LoginResponse, method string) error {
+	// Save credentials to config
+	cfg.Auth = config.AuthConfig{
+		AccessToken:  loginResp.AccessToken,
+		RefreshToken: loginResp.RefreshToken,
+		TokenExpiry:  time.Now().Add(time.Duration(loginResp.ExpiresIn) * time.Second),
+		UserID:       loginResp.User.ID,
+		Email:        loginResp.User.Email,
+		Method:       method,
+	}
 
-			if err := cfg.Save(); err != nil {
-				return fmt.Errorf("failed to save credentials: %w", err)
-			}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
 
-			// Show success message
-			fmt.Println()
-			fmt.Println("✓ Successfully authenticated!")
-			fmt.Printf("  Logged in as: \033[1m%s\033[0m\n", pollResp.User.Email)
-			fmt.Printf("  Role: %s\n", pollResp.User.Role)
-			fmt.Println()
+	// Show success message
+	fmt.Println()
+	fmt.Println("✓ Successfully authenticated!")
+	fmt.Printf("  Logged in as: \033[1m%s\033[0m\n", loginResp.User.Email)
+	fmt.Printf("  Role: %s\n", loginResp.User.Role)
+	fmt.Println()
 
-			// Show next steps
-			fmt.Println("Next Steps:")
-			fmt.Println("  chrono mcp-setup     # Configure AI editor")
-			fmt.Println("  chrono detect --save # Analyze project (optional)")
-			fmt.Println()
+	// Show next steps
+	fmt.Println("Next Steps:")
+	fmt.Println("  chrono mcp-setup     # Configure AI editor")
+	fmt.Println("  chrono detect --save # Analyze project (optional)")
+	fmt.Println()
 
-			return nil
-		}
-	}
+	return nil
 }
 
 // formatUserCode formats the user code for display (XXXX-XXXX)
@@ -154,26 +328,86 @@ func formatUserCode(code string) string {
 
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
-	Short: "Logout and clear local credentials",
-	Long:  `Logout from the Developer Platform and clear stored credentials.`,
-	RunE:  runLogout,
+	Short: "Logout, revoke the session and clear local credentials",
+	Long: `Logout from the Developer Platform, revoke the session on the server
+and clear stored credentials.
+
+With --all, the API tokens created by 'chrono mcp-setup' are revoked as well,
+and the Chrono server entry is removed from the editor MCP config files in the
+current directory (.cursor/mcp.json, .mcp.json, .codex/mcp.json,
+.gemini/settings.json). Use it when a machine is lost or compromised.
+
+A service account's access token cannot be revoked; rotate its client
+secret on the platform to cut off access before the token expires.
+
+Local credentials are cleared even if the server cannot be reached or the
+session has expired or cannot be revoked; the command then exits with an
+error so the failure is not missed.`,
+	SilenceUsage: true,
+	RunE:         runLogout,
 }
 
+var logoutAll bool
+
 func init() {
 	rootCmd.AddCommand(logoutCmd)
+	logoutCmd.Flags().BoolVar(&logoutAll, "all", false, "also revoke API tokens created by mcp-setup and remove them from editor configs")
 }
 
 func runLogout(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	cfg := GetConfig()
 
+	if cfg.HasEnvironmentCredentials() {
+		return fmt.Errorf("credentials are provided by %s; unset it to log out", cfg.CredentialSource())
+	}
+
 	// Check if logged in
 	if !cfg.IsLoggedIn() {
 		fmt.Println("Not logged in.")
+		if logoutAll {
+			removeEditorMCPConfigs()
+		}
 		return nil
 	}
 
 	fmt.Printf("Logging out %s...\n", cfg.Auth.Email)
 
+	var failures int
+	if cfg.HasValidSession() {
+		client := GetAPIClient(cfg)
+
+		if logoutAll {
+			failures += revokeCLITokens(ctx, client, cfg)
+		}
+
+		// Sessions from the device and browser flows are revoked through their
+		// refresh token and an API token login with --all. Service accounts
+		// get no refresh token, and the platform cannot revoke their access
+		// token, so it stays valid until it expires.
+		switch {
+		case cfg.Auth.RefreshToken != "":
+			if err := client.Logout(ctx, cfg.Auth.RefreshToken); err != nil {
+				fmt.Printf("⚠️  Failed to revoke session on the server: %v\n", err)
+				failures++
+			} else {
+				fmt.Println("✓ Session revoked on the server")
+			}
+		case cfg.Auth.Method == config.AuthMethodServiceAccount:
+			fmt.Printf("⚠️  The service account access token cannot be revoked and stays valid until %s\n", formatTime(cfg.Auth.TokenExpiry))
+			fmt.Printf("  Rotate the client secret of %s on the platform to cut off access now\n", cfg.Auth.ClientID)
+			failures++
+		case cfg.Auth.Method == config.AuthMethodToken && !logoutAll:
+			fmt.Println("  Note: the API token stays valid; use 'chrono logout --all' or 'chrono token revoke' to revoke it")
+		}
+	} else {
+		// Nothing can be revoked without a session, so the tokens stay valid
+		// on the server until they expire
+		fmt.Println("⚠️  Session expired; could not revoke the session or API tokens on the server")
+		fmt.Println("  Log in again and run 'chrono logout --all' to revoke them")
+		failures++
+	}
+
 	// Clear credentials
 	email := cfg.Auth.Email
 	cfg.Clear()
@@ -181,6 +415,15 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	if err := cfg.Save(); err != nil {
 		return fmt.Errorf("failed to clear credentials: %w", err)
 	}
+	fmt.Println("✓ Local credentials cleared")
+
+	if logoutAll {
+		failures += removeEditorMCPConfigs()
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("logged out locally, but %d cleanup step(s) failed; see warnings above", failures)
+	}
 
 	fmt.Printf("✓ Logged out successfully\n")
 	fmt.Printf("  Goodbye, %s!\n", email)
@@ -189,6 +432,70 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// revokeCLITokens revokes the API tokens created by mcp-setup, and the API token
+// used to log in if any. It returns the number of tokens that could not be revoked.
+func revokeCLITokens(ctx context.Context, client *api.Client, cfg *config.Config) int {
+	resp, err := client.ListTokens(ctx)
+	if err != nil {
+		fmt.Printf("⚠️  Failed to list API tokens: %v\n", err)
+		return 1
+	}
+
+	var loginToken *api.APITokenResponse
+	var failures int
+	for _, t := range resp.Tokens {
+		if cfg.Auth.Method == config.AuthMethodToken && isTokenPrefixOf(t.TokenPrefix, cfg.Auth.AccessToken) {
+			// Revoked last, since the remaining requests are authenticated with it
+			loginToken = t
+			continue
+		}
+		if t.Name != mcpTokenName {
+			continue
+		}
+		if err := client.RevokeToken(ctx, t.ID); err != nil {
+			fmt.Printf("⚠️  Failed to revoke API token %s: %v\n", t.ID, err)
+			failures++
+			continue
+		}
+		fmt.Printf("✓ Revoked API token %s (%s)\n", t.ID, t.Name)
+	}
+
+	if loginToken != nil {
+		if err := client.RevokeToken(ctx, loginToken.ID); err != nil {
+			fmt.Printf("⚠️  Failed to revoke login API token %s: %v\n", loginToken.ID, err)
+			failures++
+		} else {
+			fmt.Printf("✓ Revoked login API token %s (%s)\n", loginToken.ID, loginToken.Name)
+		}
+	}
+
+	return failures
+}
+
+// isTokenPrefixOf checks if a displayed token prefix (e.g. "dp_abc...") belongs to token
+func isTokenPrefixOf(prefix, token string) bool {
+	prefix = strings.TrimSuffix(prefix, "...")
+	return prefix != "" && strings.HasPrefix(token, prefix)
+}
+
+// removeEditorMCPConfigs removes the Chrono server from the editor MCP config
+// files in the current directory. It returns the number of files that could not be updated.
+func removeEditorMCPConfigs() int {
+	var failures int
+	for _, name := range mcpConfigFiles {
+		changed, err := removeMCPServer(filepath.Join(getWDir(), name))
+		if err != nil {
+			fmt.Printf("⚠️  Failed to update %s: %v\n", name, err)
+			failures++
+			continue
+		}
+		if changed {
+			fmt.Printf("✓ Removed %s from %s\n", mcpServerName, name)
+		}
+	}
+	return failures
+}
+
 // ============================================
 // Status Command
 // ============================================
@@ -196,8 +503,13 @@ func runLogout(cmd *cobra.Command, args []string) error {
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show current login status",
-	Long:  `Show the current authentication status and user information.`,
-	RunE:  runStatus,
+	Long: `Show the current authentication status and user information.
+
+Credentials from environment variables (CHRONO_TOKEN, CHRONO_CLIENT_ID and
+CHRONO_CLIENT_SECRET) are verified with the server, and the command exits with
+code 3 when the server rejects them.`,
+	SilenceUsage: true,
+	RunE:         runStatus,
 }
 
 func init() {
@@ -209,6 +521,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	fmt.Println("Chrono CLI Status")
 	fmt.Println(strings.Repeat("─", 42))
+	fmt.Printf("Profile: %s (%s)\n", cfg.ProfileName(), cfg.MCP.ServerURL)
 
 	if !cfg.IsLoggedIn() {
 		fmt.Println("Status: \033[33mNot logged in\033[0m")
@@ -217,7 +530,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if cfg.IsTokenExpired() {
+	if !cfg.HasValidSession() {
 		fmt.Println("Status: \033[33mSession expired\033[0m")
 		fmt.Printf("  Email: %s\n", cfg.Auth.Email)
 		fmt.Println()
@@ -225,18 +538,55 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Credentials from the environment never went through 'chrono login', so
+	// ask the server whether it accepts them before reporting a session
+	email, userID := cfg.Auth.Email, cfg.Auth.UserID
+	verified := false
+	if cfg.HasEnvironmentCredentials() {
+		user, err := GetAPIClient(cfg).GetCurrentUser(cmd.Context())
+		if err != nil {
+			if !api.IsUnauthorized(err) {
+				return fmt.Errorf("failed to verify credentials from %s: %w", cfg.CredentialSource(), err)
+			}
+			fmt.Println("Status: \033[31mCredentials rejected\033[0m")
+			fmt.Printf("  Credentials: %s\n", cfg.CredentialSource())
+			fmt.Println()
+			return withExitCode(exitCodeNoSession, "the server rejected the credentials from %s: %v", cfg.CredentialSource(), err)
+		}
+		email, userID, verified = user.Email, user.ID, true
+	}
+
 	fmt.Println("Status: \033[32mLogged in\033[0m")
-	fmt.Printf("  Email: %s\n", cfg.Auth.Email)
-	fmt.Printf("  User ID: %s\n", cfg.Auth.UserID)
+	fmt.Printf("  Credentials: %s\n", cfg.CredentialSource())
+	if email != "" {
+		fmt.Printf("  Email: %s\n", email)
+	}
+	if userID != "" {
+		fmt.Printf("  User ID: %s\n", userID)
+	}
+	if cfg.Auth.ClientID != "" {
+		fmt.Printf("  Client ID: %s\n", cfg.Auth.ClientID)
+	}
 	if !cfg.Auth.TokenExpiry.IsZero() {
 		timeUntilExpiry := time.Until(cfg.Auth.TokenExpiry)
 		if timeUntilExpiry > 0 {
 			fmt.Printf("  Token expires in: %s\n", timeUntilExpiry.Round(time.Minute))
 		} else {
-			fmt.Printf("  Token expired: %s\n", cfg.Auth.TokenExpiry.Format(time.RFC3339))
+			fmt.Printf("  Token expired: %s (will be refreshed on next request)\n", cfg.Auth.TokenExpiry.Format(time.RFC3339))
 		}
 	}
+	if cfg.CanRefresh() {
+		fmt.Println("  Auto-refresh: enabled")
+	}
+	if cfg.Defaults.Team != "" {
+		fmt.Printf("  Team: %s\n", teamDisplayName(cfg.Defaults.Team, cfg.Defaults.TeamName))
+	}
 	fmt.Println()
+	if verified {
+		fmt.Println("Verified with the server.")
+	} else {
+		fmt.Println("Run 'chrono whoami' to verify the session with the server.")
+	}
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/ChronoAIProject/chrono-cli/pkg/config"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +19,22 @@ var (
 	mcpToken  string
 )
 
+const (
+	// mcpTokenName is the name of the API tokens created by mcp-setup
+	mcpTokenName = "AI Editor MCP"
+
+	// mcpServerName is the key of the Chrono server in editor MCP configs
+	mcpServerName = "developer-platform"
+)
+
+// mcpConfigFiles lists the editor config files mcp-setup writes, relative to the project directory
+var mcpConfigFiles = []string{
+	filepath.Join(".cursor", "mcp.json"),
+	".mcp.json",
+	filepath.Join(".codex", "mcp.json"),
+	filepath.Join(".gemini", "settings.json"),
+}
+
 // mcpSetupCmd represents the mcp-setup command
 var mcpSetupCmd = &cobra.Command{
 	Use:   "mcp-setup [editor]",
@@ -45,6 +63,7 @@ func init() {
 }
 
 func runMCPSetup(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	cfg := GetConfig()
 
 	fmt.Println("========================================")
@@ -65,7 +84,7 @@ func runMCPSetup(cmd *cobra.Command, args []string) error {
 	} else {
 		// Check if user is logged in
 		fmt.Println("Checking authentication...")
-		if !cfg.IsLoggedIn() || cfg.IsTokenExpired() {
+		if !cfg.HasValidSession() {
 			fmt.Println("⚠️  Not logged in. Please authenticate:")
 			fmt.Println()
 			fmt.Println("Options:")
@@ -80,14 +99,22 @@ func runMCPSetup(cmd *cobra.Command, args []string) error {
 
 		// Create API token
 		fmt.Println("Creating API token for MCP...")
-		client := api.NewClient(cfg.MCP.ServerURL)
-		client.SetAuthToken(cfg.Auth.AccessToken)
+		client := GetAPIClient(cfg)
 
-		tokenResp, err := client.CreateToken(&api.CreateAPITokenRequest{
-			Name:      "AI Editor MCP",
+		// Scope the token to the active team, if any
+		tokenReq := &api.CreateAPITokenRequest{
+			Name:      mcpTokenName,
 			Scope:     "personal",
 			ExpiresIn: 365 * 24 * 60 * 60, // 1 year
-		})
+		}
+		if cfg.Defaults.Team != "" {
+			tokenReq.Scope = "team"
+			tokenReq.TeamID = cfg.Defaults.Team
+			fmt.Printf("  Team: %s\n", teamDisplayName(cfg.Defaults.Team, cfg.Defaults.TeamName))
+		}
+
+		// The idempotency key makes the request safe to retry without creating a second token
+		tokenResp, err := client.CreateToken(api.WithIdempotencyKey(ctx, api.NewIdempotencyKey()), tokenReq)
 		if err != nil {
 			return fmt.Errorf("failed to create API token: %w", err)
 		}
@@ -103,6 +130,9 @@ func runMCPSetup(cmd *cobra.Command, args []string) error {
 	if editor == "" && len(args) > 0 {
 		editor = args[0]
 	}
+	if editor == "" {
+		editor = cfg.Defaults.Editor
+	}
 
 	var selectionIdx int
 	if editor != "" {
@@ -135,13 +165,13 @@ func runMCPSetup(cmd *cobra.Command, args []string) error {
 	// Show configuration based on selection
 	switch selectionIdx {
 	case 0:
-		showCursorConfig(serverURL, token)
+		showCursorConfig(ctx, serverURL, token, &cfg.HTTP)
 	case 1:
-		showClaudeCodeConfig(serverURL, token)
+		showClaudeCodeConfig(ctx, serverURL, token, &cfg.HTTP)
 	case 2:
-		showCodexConfig(serverURL, token)
+		showCodexConfig(ctx, serverURL, token, &cfg.HTTP)
 	case 3:
-		showGeminiConfig(serverURL, token)
+		showGeminiConfig(ctx, serverURL, token, &cfg.HTTP)
 	}
 
 	// Show available tools
@@ -150,7 +180,7 @@ func runMCPSetup(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func showCursorConfig(serverURL, token string) {
+func showCursorConfig(ctx context.Context, serverURL, token string, hc *config.HTTPConfig) {
 	fmt.Println("========================================")
 	fmt.Println("Cursor IDE Configuration")
 	fmt.Println("========================================")
@@ -179,7 +209,7 @@ func showCursorConfig(serverURL, token string) {
 
 	// Verify MCP connection
 	fmt.Println("Verifying MCP connection...")
-	if err := testMCPConnection(serverURL, token); err != nil {
+	if err := testMCPConnection(ctx, serverURL, token, hc); err != nil {
 		fmt.Printf("⚠️  MCP connection test failed: %v\n", err)
 		fmt.Println("  Please check your network and try again")
 		fmt.Println()
@@ -203,7 +233,7 @@ func getWDir() string {
 	return wd
 }
 
-func showClaudeCodeConfig(serverURL, token string) {
+func showClaudeCodeConfig(ctx context.Context, serverURL, token string, hc *config.HTTPConfig) {
 	fmt.Println("========================================")
 	fmt.Println("Claude Code Configuration")
 	fmt.Println("========================================")
@@ -226,7 +256,7 @@ func showClaudeCodeConfig(serverURL, token string) {
 
 	// Verify MCP connection
 	fmt.Println("Verifying MCP connection...")
-	if err := testMCPConnection(serverURL, token); err != nil {
+	if err := testMCPConnection(ctx, serverURL, token, hc); err != nil {
 		fmt.Printf("⚠️  MCP connection test failed: %v\n", err)
 		fmt.Println("  Please check your network and try again")
 		fmt.Println()
@@ -241,7 +271,7 @@ func showClaudeCodeConfig(serverURL, token string) {
 	fmt.Println()
 }
 
-func showCodexConfig(serverURL, token string) {
+func showCodexConfig(ctx context.Context, serverURL, token string, hc *config.HTTPConfig) {
 	fmt.Println("========================================")
 	fmt.Println("Codex Configuration")
 	fmt.Println("========================================")
@@ -271,7 +301,7 @@ func showCodexConfig(serverURL, token string) {
 
 	// Verify MCP connection
 	fmt.Println("Verifying MCP connection...")
-	if err := testMCPConnection(serverURL, token); err != nil {
+	if err := testMCPConnection(ctx, serverURL, token, hc); err != nil {
 		fmt.Printf("⚠️  MCP connection test failed: %v\n", err)
 		fmt.Println("  Please check your network and try again")
 		fmt.Println()
@@ -286,7 +316,7 @@ func showCodexConfig(serverURL, token string) {
 	fmt.Println()
 }
 
-func showGeminiConfig(serverURL, token string) {
+func showGeminiConfig(ctx context.Context, serverURL, token string, hc *config.HTTPConfig) {
 	fmt.Println("========================================")
 	fmt.Println("Gemini CLI Configuration")
 	fmt.Println("========================================")
@@ -316,7 +346,7 @@ func showGeminiConfig(serverURL, token string) {
 
 	// Verify MCP connection
 	fmt.Println("Verifying MCP connection...")
-	if err := testMCPConnection(serverURL, token); err != nil {
+	if err := testMCPConnection(ctx, serverURL, token, hc); err != nil {
 		fmt.Printf("⚠️  MCP connection test failed: %v\n", err)
 		fmt.Println("  Please check your network and try again")
 		fmt.Println()
@@ -438,7 +468,7 @@ func mergeCursorConfig(configPath, serverURL, token string) error {
 	}
 
 	// Add or update the developer-platform server
-	config.MCPServers["developer-platform"] = map[string]interface{}{
+	config.MCPServers[mcpServerName] = map[string]interface{}{
 		"url": serverURL + "/mcp",
 		"headers": map[string]string{
 			"Authorization": "Bearer " + token,
@@ -485,7 +515,7 @@ func mergeMCPConfig(configPath, serverURL, token string) error {
 	}
 
 	// Add or update the developer-platform server
-	config.MCPServers["developer-platform"] = map[string]interface{}{
+	config.MCPServers[mcpServerName] = map[string]interface{}{
 		"url": serverURL + "/mcp",
 		"headers": map[string]string{
 			"Authorization": "Bearer " + token,
@@ -506,6 +536,47 @@ func mergeMCPConfig(configPath, serverURL, token string) error {
 	return nil
 }
 
+// removeMCPServer removes the Chrono server entry from an editor MCP config file.
+// Other servers and top-level settings are preserved. It reports whether the file was changed.
+func removeMCPServer(configPath string) (bool, error) {
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(data, &config); err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+
+	var servers map[string]json.RawMessage
+	if raw, ok := config["mcpServers"]; !ok || json.Unmarshal(raw, &servers) != nil {
+		return false, nil
+	}
+	if _, ok := servers[mcpServerName]; !ok {
+		return false, nil
+	}
+
+	delete(servers, mcpServerName)
+	if config["mcpServers"], err = json.Marshal(servers); err != nil {
+		return false, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	data, err = json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		return false, fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return true, nil
+}
+
 // printMCPConfigJSON prints the standard MCP config JSON format
 func printMCPConfigJSON(serverURL, token string) {
 	fmt.Println("```json")
@@ -524,11 +595,11 @@ func printMCPConfigJSON(serverURL, token string) {
 }
 
 // testMCPConnection tests the MCP connection to verify it works
-func testMCPConnection(serverURL, token string) error {
-	client := api.NewClient(serverURL)
+func testMCPConnection(ctx context.Context, serverURL, token string, hc *config.HTTPConfig) error {
+	client := newAPIClient(serverURL, hc)
 	client.SetAPIToken(token)
 
-	resp, err := client.GetMCPInfo()
+	resp, err := client.GetMCPInfo(ctx)
 	if err != nil {
 		return fmt.Errorf("connection failed: %w", err)
 	}
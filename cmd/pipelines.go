@@ -0,0 +1,546 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/ChronoAIProject/chrono-cli/pkg/gitinfo"
+	"github.com/ChronoAIProject/chrono-cli/pkg/pipeline"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pipelinesJSON        bool
+	pipelinesList        listFlags
+	pipelinesProject     string
+	pipelinesEnvironment string
+	pipelinesYes         bool
+
+	pipelinesRepository  string
+	pipelinesBranch      string
+	pipelinesAppName     string
+	pipelinesName        string
+	pipelinesPort        int
+	pipelinesMiddleware  []string
+	pipelinesEnv         []string
+	pipelinesFrontendEnv []string
+	pipelinesSecrets     []string
+)
+
+// pipelinesCmd represents the pipelines command
+var pipelinesCmd = &cobra.Command{
+	Use:     "pipelines",
+	Aliases: []string{"pipeline"},
+	Short:   "Manage deployment pipelines",
+	Long: `Create, list, inspect, update and delete pipelines.
+
+A pipeline builds and deploys one branch of a repository. 'chrono pipelines
+create' builds the pipeline from the metadata saved by 'chrono detect --save'
+and creates it in the project linked with 'chrono link'.`,
+}
+
+var pipelinesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a pipeline for the current repository and branch",
+	Long: `Create a pipeline from .chrono/metadata.yaml.
+
+The app name is derived as {repo}-{branch} and used in the application URLs.
+Frontend variables are sent as frontend env vars. Backend variables are sent
+as env vars, or as secrets when their name contains KEY, SECRET, TOKEN,
+PASSWORD, CREDENTIAL, AUTH or PRIVATE. Variables detected without a value
+are left out; set them with --env, --frontend-env and --secret.
+
+The configuration is shown for confirmation before the pipeline is created.
+
+Examples:
+  chrono detect --save && chrono pipelines create
+  chrono pipelines create --secret OPENAI_API_KEY=sk-... --yes
+  chrono pipelines create --app-name shop --branch main --project shop`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runPipelinesCreate,
+}
+
+var pipelinesListCmd = &cobra.Command{
+	Use:          "list",
+	Aliases:      []string{"ls"},
+	Short:        "List pipelines",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runPipelinesList,
+}
+
+var pipelinesGetCmd = &cobra.Command{
+	Use:          "get <name-or-id>",
+	Short:        "Show details of a pipeline",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runPipelinesGet,
+}
+
+var pipelinesUpdateCmd = &cobra.Command{
+	Use:   "update <name-or-id>",
+	Short: "Update the configuration of a pipeline",
+	Long: `Update the configuration of a pipeline. A deployed application restarts
+with the new configuration.
+
+Variables given with an empty value (KEY=) are removed.
+
+Examples:
+  chrono pipelines update shop-main --env LOG_LEVEL=debug
+  chrono pipelines update shop-main --secret JWT_SECRET=... --secret OLD_KEY=
+  chrono pipelines update shop-main --middleware mongodb,redis --port 3000`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runPipelinesUpdate,
+}
+
+var pipelinesDeleteCmd = &cobra.Command{
+	Use:          "delete <name-or-id>",
+	Short:        "Delete a pipeline and its deployment",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runPipelinesDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(pipelinesCmd)
+	pipelinesCmd.AddCommand(pipelinesCreateCmd)
+	pipelinesCmd.AddCommand(pipelinesListCmd)
+	pipelinesCmd.AddCommand(pipelinesGetCmd)
+	pipelinesCmd.AddCommand(pipelinesUpdateCmd)
+	pipelinesCmd.AddCommand(pipelinesDeleteCmd)
+
+	pipelinesCreateCmd.Flags().StringVar(&pipelinesProject, "project", "", "project name or ID (default: the linked project)")
+	pipelinesCreateCmd.Flags().StringVar(&pipelinesRepository, "repository", "", "repository as owner/repo (default: the origin remote)")
+	pipelinesCreateCmd.Flags().StringVar(&pipelinesBranch, "branch", "", "branch to deploy (default: the current branch)")
+	pipelinesCreateCmd.Flags().StringVar(&pipelinesAppName, "app-name", "", "application name (default: {repo}-{branch})")
+	pipelinesCreateCmd.Flags().StringVar(&pipelinesEnvironment, "environment", "", "environment (default: the platform default)")
+	pipelinesCreateCmd.Flags().StringArrayVar(&pipelinesEnv, "env", nil, "backend env var as KEY=VALUE (repeatable)")
+	pipelinesCreateCmd.Flags().StringArrayVar(&pipelinesFrontendEnv, "frontend-env", nil, "frontend env var as KEY=VALUE (repeatable)")
+	pipelinesCreateCmd.Flags().StringArrayVar(&pipelinesSecrets, "secret", nil, "secret as KEY=VALUE (repeatable)")
+	pipelinesCreateCmd.Flags().BoolVarP(&pipelinesYes, "yes", "y", false, "skip confirmation")
+	pipelinesCreateCmd.Flags().BoolVar(&pipelinesJSON, "json", false, "Output as JSON (requires --yes)")
+
+	pipelinesListCmd.Flags().StringVar(&pipelinesProject, "project", "", "only list pipelines of this project (name or ID)")
+	pipelinesListCmd.Flags().StringVar(&pipelinesEnvironment, "environment", "", "only list pipelines of this environment")
+	pipelinesListCmd.Flags().BoolVar(&pipelinesJSON, "json", false, "Output as JSON")
+	pipelinesList.register(pipelinesListCmd)
+
+	pipelinesGetCmd.Flags().BoolVar(&pipelinesJSON, "json", false, "Output as JSON")
+
+	pipelinesUpdateCmd.Flags().StringVar(&pipelinesName, "name", "", "display name")
+	pipelinesUpdateCmd.Flags().StringVar(&pipelinesBranch, "branch", "", "branch to deploy")
+	pipelinesUpdateCmd.Flags().IntVar(&pipelinesPort, "port", 0, "port the backend listens on")
+	pipelinesUpdateCmd.Flags().StringSliceVar(&pipelinesMiddleware, "middleware", nil, "middleware to provision (mongodb, redis, postgresql, mysql); \"\" removes all")
+	pipelinesUpdateCmd.Flags().StringArrayVar(&pipelinesEnv, "env", nil, "set or, with an empty value, remove a backend env var (repeatable)")
+	pipelinesUpdateCmd.Flags().StringArrayVar(&pipelinesFrontendEnv, "frontend-env", nil, "set or remove a frontend env var (repeatable)")
+	pipelinesUpdateCmd.Flags().StringArrayVar(&pipelinesSecrets, "secret", nil, "set or remove a secret (repeatable)")
+	pipelinesUpdateCmd.Flags().BoolVar(&pipelinesJSON, "json", false, "Output as JSON")
+
+	pipelinesDeleteCmd.Flags().BoolVarP(&pipelinesYes, "yes", "y", false, "skip confirmation")
+}
+
+func runPipelinesCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+	if pipelinesJSON && !pipelinesYes {
+		return fmt.Errorf("--json requires --yes")
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	root := projectRoot(wd)
+	meta, err := pipeline.LoadMetadata(root)
+	if err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	projectID, projectName, err := pipelineProject(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var repository, branch string
+	if status, err := gitinfo.Inspect(root); err == nil {
+		repository, branch = status.Repository(), status.Branch
+	}
+	if pipelinesRepository != "" {
+		repository = pipelinesRepository
+	}
+	if pipelinesBranch != "" {
+		branch = pipelinesBranch
+	}
+	if repository == "" {
+		return fmt.Errorf("cannot determine the repository from the origin remote; set it with --repository owner/repo")
+	}
+	if branch == "" {
+		return fmt.Errorf("cannot determine the current branch; set it with --branch")
+	}
+
+	opts := pipeline.Options{
+		ProjectID:   projectID,
+		Repository:  repository,
+		Branch:      branch,
+		Environment: pipelinesEnvironment,
+		AppName:     pipelinesAppName,
+	}
+	if opts.BackendEnv, err = parseVars("--env", pipelinesEnv); err != nil {
+		return err
+	}
+	if opts.FrontendEnv, err = parseVars("--frontend-env", pipelinesFrontendEnv); err != nil {
+		return err
+	}
+	if opts.Secrets, err = parseVars("--secret", pipelinesSecrets); err != nil {
+		return err
+	}
+
+	plan, err := pipeline.FromMetadata(meta, opts)
+	if err != nil {
+		return err
+	}
+
+	if !pipelinesJSON {
+		printPipelinePlan(plan, fmt.Sprintf("%s (%s)", valueOrDash(projectName), projectID))
+	}
+	if !pipelinesYes {
+		prompt := promptui.Prompt{
+			Label:     "Create this pipeline",
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	p, err := client.CreatePipeline(ctx, plan.Request)
+	if err != nil {
+		return fmt.Errorf("failed to create pipeline: %w", err)
+	}
+
+	if pipelinesJSON {
+		return printJSON(p)
+	}
+	fmt.Printf("✓ Created pipeline %s (%s)\n", p.AppName, p.ID)
+	printPipelineURLs(p)
+	fmt.Println()
+	fmt.Println("Deploy it with: chrono deploy")
+	return nil
+}
+
+// pipelineProject returns the project named by --project, or the linked project
+func pipelineProject(ctx context.Context, client *api.Client) (id, name string, err error) {
+	if pipelinesProject != "" {
+		project, err := resolveProject(ctx, client, pipelinesProject)
+		if err != nil {
+			return "", "", err
+		}
+		return project.ID, project.Name, nil
+	}
+
+	link, err := linkedProject()
+	if err != nil {
+		return "", "", err
+	}
+	return link.ProjectID, link.ProjectName, nil
+}
+
+// printPipelinePlan shows the pipeline about to be created, masking secrets
+func printPipelinePlan(plan *pipeline.Plan, project string) {
+	req := plan.Request
+	fmt.Printf("Pipeline for %s (%s)\n", req.Repository, req.Branch)
+	fmt.Println()
+	fmt.Printf("  Project:      %s\n", project)
+	fmt.Printf("  App name:     %s\n", req.AppName)
+	fmt.Printf("  Type:         %s\n", req.AppType)
+	if req.Environment != "" {
+		fmt.Printf("  Environment:  %s\n", req.Environment)
+	}
+	if req.BackendPort > 0 {
+		fmt.Printf("  Backend port: %d\n", req.BackendPort)
+	}
+	fmt.Printf("  Middleware:   %s\n", valueOrDash(strings.Join(req.Middleware, ", ")))
+	printVars("Frontend env", req.FrontendEnvVars, false)
+	printVars("Backend env", req.BackendEnvVars, false)
+	printVars("Secrets", req.Secrets, true)
+
+	if len(plan.Unset) > 0 {
+		fmt.Println()
+		fmt.Printf("⚠ No value for %s; these variables are left out.\n", strings.Join(plan.Unset, ", "))
+		fmt.Println("  Set them with --env, --frontend-env or --secret, or later with 'chrono pipelines update'.")
+	}
+	fmt.Println()
+}
+
+// printVars prints variables sorted by name, one per line
+func printVars(label string, vars map[string]string, masked bool) {
+	if len(vars) == 0 {
+		return
+	}
+	fmt.Printf("  %s:\n", label)
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := vars[name]
+		if masked {
+			value = api.MaskedValue
+		}
+		fmt.Printf("    %s=%s\n", name, value)
+	}
+}
+
+// printPipelineURLs prints the addresses the application is served at
+func printPipelineURLs(p *api.Pipeline) {
+	if p.FrontendURL != "" {
+		fmt.Printf("  Frontend: %s\n", p.FrontendURL)
+	}
+	if p.BackendURL != "" {
+		fmt.Printf("  Backend:  %s\n", p.BackendURL)
+	}
+}
+
+// parseVars parses KEY=VALUE flag values. Values may be empty.
+func parseVars(flag string, values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	vars := make(map[string]string, len(values))
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid %s %q: use KEY=VALUE", flag, v)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+func runPipelinesList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+	opts, err := pipelinesList.options()
+	if err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	filter := api.PipelineFilter{Environment: pipelinesEnvironment}
+	if pipelinesProject != "" {
+		project, err := resolveProject(ctx, client, pipelinesProject)
+		if err != nil {
+			return err
+		}
+		filter.ProjectID = project.ID
+	}
+
+	it := client.IterPipelines(filter, opts)
+	header := []string{"ID", "NAME", "REPOSITORY", "BRANCH", "TYPE", "STATUS", "URL"}
+	count, err := printList(ctx, it, pipelinesJSON, header, pipelineRow)
+	if err != nil {
+		return fmt.Errorf("failed to list pipelines: %w", err)
+	}
+
+	if count == 0 && !pipelinesJSON {
+		fmt.Println("No pipelines found. Create one with 'chrono pipelines create'.")
+		return nil
+	}
+	pipelinesList.printTruncated(count, it.Total(), "pipelines")
+	return nil
+}
+
+// pipelineRow returns the table columns of a pipeline
+func pipelineRow(p *api.Pipeline) []string {
+	url := p.FrontendURL
+	if url == "" {
+		url = p.BackendURL
+	}
+	return []string{p.ID, p.AppName, p.Repository, p.Branch, p.AppType, p.Status, valueOrDash(url)}
+}
+
+func runPipelinesGet(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	p, err := resolvePipeline(cmd.Context(), client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if pipelinesJSON {
+		return printJSON(p)
+	}
+	printPipeline(p)
+	return nil
+}
+
+// printPipeline prints the configuration and addresses of a pipeline
+func printPipeline(p *api.Pipeline) {
+	fmt.Printf("ID:           %s\n", p.ID)
+	fmt.Printf("Name:         %s\n", p.Name)
+	fmt.Printf("App name:     %s\n", p.AppName)
+	fmt.Printf("Project:      %s\n", p.ProjectID)
+	fmt.Printf("Repository:   %s\n", p.Repository)
+	fmt.Printf("Branch:       %s\n", p.Branch)
+	fmt.Printf("Environment:  %s\n", valueOrDash(p.Environment))
+	fmt.Printf("Type:         %s\n", p.AppType)
+	fmt.Printf("Status:       %s\n", p.Status)
+	if p.BackendPort > 0 {
+		fmt.Printf("Backend port: %d\n", p.BackendPort)
+	}
+	fmt.Printf("Middleware:   %s\n", valueOrDash(strings.Join(p.Middleware, ", ")))
+	fmt.Printf("Secrets:      %s\n", valueOrDash(strings.Join(p.SecretNames, ", ")))
+	if p.FrontendURL != "" {
+		fmt.Printf("Frontend URL: %s\n", p.FrontendURL)
+	}
+	if p.BackendURL != "" {
+		fmt.Printf("Backend URL:  %s\n", p.BackendURL)
+	}
+	fmt.Printf("Last run:     %s\n", valueOrDash(p.LastRunID))
+	fmt.Printf("Created:      %s\n", formatTime(p.CreatedAt))
+	fmt.Printf("Updated:      %s\n", formatTime(p.UpdatedAt))
+
+	if len(p.FrontendEnvVars) > 0 || len(p.BackendEnvVars) > 0 {
+		fmt.Println()
+		printVars("Frontend env", p.FrontendEnvVars, false)
+		printVars("Backend env", p.BackendEnvVars, false)
+	}
+}
+
+func runPipelinesUpdate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	var req api.UpdatePipelineRequest
+	var err error
+	flags := cmd.Flags()
+	if flags.Changed("name") {
+		req.Name = &pipelinesName
+	}
+	if flags.Changed("branch") {
+		req.Branch = &pipelinesBranch
+	}
+	if flags.Changed("port") {
+		req.BackendPort = &pipelinesPort
+	}
+	if flags.Changed("middleware") {
+		req.Middleware = &pipelinesMiddleware
+	}
+	if req.BackendEnvVars, err = parseVars("--env", pipelinesEnv); err != nil {
+		return err
+	}
+	if req.FrontendEnvVars, err = parseVars("--frontend-env", pipelinesFrontendEnv); err != nil {
+		return err
+	}
+	if req.Secrets, err = parseVars("--secret", pipelinesSecrets); err != nil {
+		return err
+	}
+	if req.Name == nil && req.Branch == nil && req.BackendPort == nil && req.Middleware == nil &&
+		req.BackendEnvVars == nil && req.FrontendEnvVars == nil && req.Secrets == nil {
+		return fmt.Errorf("nothing to update. See 'chrono pipelines update --help' for the available flags")
+	}
+
+	client := GetAPIClient(cfg)
+	p, err := resolvePipeline(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	p, err = client.UpdatePipeline(ctx, p.ID, &req)
+	if err != nil {
+		return fmt.Errorf("failed to update pipeline: %w", err)
+	}
+
+	if pipelinesJSON {
+		return printJSON(p)
+	}
+	fmt.Printf("✓ Updated pipeline %s (%s)\n", p.AppName, p.ID)
+	if p.LastRunID != "" {
+		fmt.Println("  The running application restarts with the new configuration.")
+	}
+	return nil
+}
+
+func runPipelinesDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	p, err := resolvePipeline(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !pipelinesYes {
+		prompt := promptui.Prompt{
+			Label:     fmt.Sprintf("Delete pipeline %s (%s) and its deployment", p.AppName, p.ID),
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	if err := client.DeletePipeline(ctx, p.ID); err != nil {
+		return fmt.Errorf("failed to delete pipeline: %w", err)
+	}
+	fmt.Printf("✓ Deleted pipeline %s (%s)\n", p.AppName, p.ID)
+	return nil
+}
+
+// resolvePipeline finds a pipeline by ID, app name or case-insensitive name
+func resolvePipeline(ctx context.Context, client *api.Client, ref string) (*api.Pipeline, error) {
+	p, err := client.GetPipeline(ctx, ref)
+	if err == nil {
+		return p, nil
+	}
+	if !api.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get pipeline: %w", err)
+	}
+
+	var matches []*api.Pipeline
+	it := client.IterPipelines(api.PipelineFilter{}, api.ListOptions{})
+	for it.Next(ctx) {
+		if p := it.Item(); p.AppName == ref || strings.EqualFold(p.Name, ref) {
+			matches = append(matches, p)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, withExitCode(exitCodeNotFound, "pipeline %q not found. Run 'chrono pipelines list' to see your pipelines", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("pipeline name %q is ambiguous; use the pipeline ID", ref)
+	}
+}
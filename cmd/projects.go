@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectsJSON        bool
+	projectsList        listFlags
+	projectsDescription string
+	projectsLink        bool
+	projectsYes         bool
+)
+
+// projectsCmd represents the projects command
+var projectsCmd = &cobra.Command{
+	Use:     "projects",
+	Aliases: []string{"project"},
+	Short:   "Manage platform projects",
+	Long: `List, create, inspect and delete projects on the Developer Platform.
+
+A project groups the pipelines of an application. Projects belong to the
+active team ('chrono team use'). Link a repository to a project with
+'chrono link' so pipeline commands know where to create pipelines.`,
+}
+
+var projectsListCmd = &cobra.Command{
+	Use:          "list",
+	Aliases:      []string{"ls"},
+	Short:        "List projects",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runProjectsList,
+}
+
+var projectsCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a project",
+	Long: `Create a project in the active team.
+
+Examples:
+  chrono projects create shop --description "Storefront and API"
+  chrono projects create shop --link     # also link the current repository`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runProjectsCreate,
+}
+
+var projectsGetCmd = &cobra.Command{
+	Use:          "get <name-or-id>",
+	Short:        "Show details of a project",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runProjectsGet,
+}
+
+var projectsDeleteCmd = &cobra.Command{
+	Use:   "delete <name-or-id>",
+	Short: "Delete a project",
+	Long: `Delete a project. The platform refuses to delete projects that still
+have pipelines; delete them first with 'chrono pipelines delete'.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runProjectsDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(projectsCmd)
+	projectsCmd.AddCommand(projectsListCmd)
+	projectsCmd.AddCommand(projectsCreateCmd)
+	projectsCmd.AddCommand(projectsGetCmd)
+	projectsCmd.AddCommand(projectsDeleteCmd)
+
+	projectsListCmd.Flags().BoolVar(&projectsJSON, "json", false, "Output as JSON")
+	projectsList.register(projectsListCmd)
+	projectsCreateCmd.Flags().BoolVar(&projectsJSON, "json", false, "Output as JSON")
+	projectsCreateCmd.Flags().StringVar(&projectsDescription, "description", "", "project description")
+	projectsCreateCmd.Flags().BoolVar(&projectsLink, "link", false, "link the current repository to the new project")
+	projectsGetCmd.Flags().BoolVar(&projectsJSON, "json", false, "Output as JSON")
+	projectsDeleteCmd.Flags().BoolVarP(&projectsYes, "yes", "y", false, "skip confirmation")
+}
+
+func runProjectsList(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+	opts, err := projectsList.options()
+	if err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	it := client.IterProjects(opts)
+	count, err := printList(cmd.Context(), it, projectsJSON, []string{"ID", "NAME", "DESCRIPTION", "CREATED"}, projectRow)
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	if count == 0 && !projectsJSON {
+		fmt.Println("No projects found. Create one with 'chrono projects create <name>'.")
+		return nil
+	}
+	projectsList.printTruncated(count, it.Total(), "projects")
+	return nil
+}
+
+// projectRow returns the table columns of a project
+func projectRow(project *api.Project) []string {
+	return []string{project.ID, project.Name, valueOrDash(project.Description), formatTime(project.CreatedAt)}
+}
+
+func runProjectsCreate(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	project, err := client.CreateProject(cmd.Context(), &api.CreateProjectRequest{
+		Name:        args[0],
+		Description: projectsDescription,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	if !projectsJSON {
+		fmt.Printf("✓ Created project %q (%s)\n", project.Name, project.ID)
+	}
+	if projectsLink {
+		if err := linkProject(cfg.MCP.ServerURL, project, projectsJSON); err != nil {
+			return err
+		}
+	}
+
+	if projectsJSON {
+		return printJSON(project)
+	}
+	return nil
+}
+
+func runProjectsGet(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	project, err := resolveProject(cmd.Context(), client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if projectsJSON {
+		return printJSON(project)
+	}
+
+	fmt.Printf("ID:          %s\n", project.ID)
+	fmt.Printf("Name:        %s\n", project.Name)
+	if project.Description != "" {
+		fmt.Printf("Description: %s\n", project.Description)
+	}
+	fmt.Printf("Team:        %s\n", valueOrDash(project.TeamID))
+	fmt.Printf("Created:     %s\n", formatTime(project.CreatedAt))
+	return nil
+}
+
+func runProjectsDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	project, err := resolveProject(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !projectsYes {
+		prompt := promptui.Prompt{
+			Label:     fmt.Sprintf("Delete project %q (%s)", project.Name, project.ID),
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	if err := client.DeleteProject(ctx, project.ID); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	fmt.Printf("✓ Deleted project %q (%s)\n", project.Name, project.ID)
+	return nil
+}
+
+// resolveProject finds a project by ID or case-insensitive name
+func resolveProject(ctx context.Context, client *api.Client, ref string) (*api.Project, error) {
+	project, err := client.GetProject(ctx, ref)
+	if err == nil {
+		return project, nil
+	}
+	if !api.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	var matches []*api.Project
+	it := client.IterProjects(api.ListOptions{})
+	for it.Next(ctx) {
+		if strings.EqualFold(it.Item().Name, ref) {
+			matches = append(matches, it.Item())
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, withExitCode(exitCodeNotFound, "project %q not found. Run 'chrono projects list' to see your projects", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("project name %q is ambiguous; use the project ID", ref)
+	}
+}
@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
 	"github.com/ChronoAIProject/chrono-cli/pkg/config"
 )
 
@@ -24,13 +30,62 @@ Features:
 	CompletionOptions: cobra.CompletionOptions{
 		DisableDefaultCmd: true,
 	},
+	PersistentPreRunE: applyTimeout,
+	// Errors are printed by execute, together with hints
+	SilenceErrors: true,
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	os.Exit(execute())
+}
+
+// execute runs the root command and returns the process exit code.
+// Ctrl-C cancels the command context, so long-running operations stop
+// cleanly; a second Ctrl-C terminates the process immediately.
+func execute() int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	if cancelTimeout != nil {
+		cancelTimeout()
+	}
+	writeTrace()
+	if err == nil {
+		return 0
+	}
+
+	fmt.Fprintln(os.Stderr, err)
+	if errors.Is(err, context.DeadlineExceeded) && viper.GetDuration("timeout") > 0 {
+		fmt.Fprintf(os.Stderr, "Timed out after %s (--timeout)\n", viper.GetDuration("timeout"))
+	}
+
+	var apiErr *api.Error
+	if errors.As(err, &apiErr) && apiErr.RequestID != "" {
+		fmt.Fprintf(os.Stderr, "Request ID: %s\n", apiErr.RequestID)
+	}
+	if hint := errorHint(err); hint != "" {
+		fmt.Fprintf(os.Stderr, "Hint: %s\n", hint)
+	}
+
+	return exitCode(err)
+}
+
+// cancelTimeout releases the deadline set by --timeout
+var cancelTimeout context.CancelFunc
+
+// applyTimeout bounds the command context by the --timeout flag
+func applyTimeout(cmd *cobra.Command, args []string) error {
+	if timeout := viper.GetDuration("timeout"); timeout > 0 {
+		var ctx context.Context
+		ctx, cancelTimeout = context.WithTimeout(cmd.Context(), timeout)
+		cmd.SetContext(ctx)
 	}
+	return nil
 }
 
 func init() {
@@ -42,11 +97,27 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.chrono/config.yaml)")
 	rootCmd.PersistentFlags().String("api-url", "", "API server URL (overrides config file)")
-	rootCmd.PersistentFlags().Bool("debug", false, "enable debug output")
+	rootCmd.PersistentFlags().String("profile", "", "config profile to use (overrides current context, env CHRONO_PROFILE)")
+	rootCmd.PersistentFlags().Bool("debug", false, "trace HTTP requests and responses to stderr (secrets are redacted)")
+	rootCmd.PersistentFlags().String("trace-file", "", "record HTTP traffic to a HAR file (secrets are redacted)")
+	rootCmd.PersistentFlags().String("ca-file", "", "PEM file of extra CA certificates to trust (env CHRONO_CA_FILE)")
+	rootCmd.PersistentFlags().String("client-cert", "", "client certificate (PEM) for platforms that require mTLS")
+	rootCmd.PersistentFlags().String("client-key", "", "private key (PEM) of the client certificate")
+	rootCmd.PersistentFlags().Bool("insecure", false, "skip TLS certificate verification (unsafe)")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "overall deadline for the command, e.g. 30s or 5m (default no limit)")
 
 	// Bind flags to viper
 	viper.BindPFlag("api-url", rootCmd.PersistentFlags().Lookup("api-url"))
+	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+	viper.BindEnv("profile", "CHRONO_PROFILE")
 	viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
+	viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	viper.BindPFlag("trace-file", rootCmd.PersistentFlags().Lookup("trace-file"))
+	viper.BindPFlag("ca-file", rootCmd.PersistentFlags().Lookup("ca-file"))
+	viper.BindEnv("ca-file", "CHRONO_CA_FILE")
+	viper.BindPFlag("client-cert", rootCmd.PersistentFlags().Lookup("client-cert"))
+	viper.BindPFlag("client-key", rootCmd.PersistentFlags().Lookup("client-key"))
+	viper.BindPFlag("insecure", rootCmd.PersistentFlags().Lookup("insecure"))
 }
 
 func initConfig() {
@@ -73,14 +144,17 @@ func initConfig() {
 	}
 }
 
-// GetConfig loads and returns the configuration
+// GetConfig loads and returns the configuration for the active profile
 func GetConfig() *config.Config {
-	cfg, err := config.Load()
+	cfg, err := config.LoadProfile(viper.GetString("profile"))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Apply credentials and API URL from the environment (CHRONO_TOKEN, CHRONO_API_URL, ...)
+	cfg.ApplyEnvironment()
+
 	// Apply API URL overrides from flags or config
 	if viper.IsSet("api-url") {
 		cfg.MCP.ServerURL = viper.GetString("api-url")
@@ -89,8 +163,178 @@ func GetConfig() *config.Config {
 	return cfg
 }
 
-// GetAPIClient returns an API client configured with the current settings
-func GetAPIClient() *config.Config {
-	cfg := GetConfig()
-	return cfg
+// requireSession returns an error when the active profile has no usable credentials
+func requireSession(cfg *config.Config) error {
+	if !cfg.HasValidSession() {
+		return fmt.Errorf("not logged in. Run 'chrono login' first")
+	}
+	return nil
+}
+
+// GetAPIClient returns an API client configured with the current settings.
+// The client refreshes the session on its own and saves rotated tokens to the config file.
+func GetAPIClient(cfg *config.Config) *api.Client {
+	client := newAPIClient(cfg.MCP.ServerURL, &cfg.HTTP)
+	client.SetAuthToken(cfg.Auth.AccessToken)
+	client.SetRefreshToken(cfg.Auth.RefreshToken)
+	client.SetTokenExpiry(cfg.Auth.TokenExpiry)
+	client.SetTeam(cfg.Defaults.Team)
+	if cfg.Auth.Method == config.AuthMethodServiceAccount {
+		client.SetClientCredentials(cfg.Auth.ClientID, cfg.Auth.ClientSecret)
+	}
+	client.OnTokenRefresh(func(resp *api.LoginResponse) {
+		cfg.Auth.AccessToken = resp.AccessToken
+		if resp.RefreshToken != "" {
+			cfg.Auth.RefreshToken = resp.RefreshToken
+		}
+		cfg.Auth.TokenExpiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+
+		// Credentials from the environment are never written to the config file
+		if cfg.HasEnvironmentCredentials() {
+			return
+		}
+		if err := cfg.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save refreshed credentials: %v\n", err)
+		}
+	})
+	return client
+}
+
+// traceRecorder collects the HTTP traffic of all clients for --trace-file
+var traceRecorder *api.HARRecorder
+
+// cassette records or replays API interactions for CHRONO_RECORD and CHRONO_REPLAY
+var cassette *api.Cassette
+
+// newAPIClient returns an unauthenticated API client with the transport and
+// retry policy of hc, and the cassette, --debug and --trace-file
+// instrumentation applied
+func newAPIClient(serverURL string, hc *config.HTTPConfig) *api.Client {
+	client := api.NewClient(serverURL)
+	client.SetTransport(httpTransport(hc))
+	client.SetRetryPolicy(retryPolicy(hc.Retry))
+	client.SetVersion(Version)
+	client.OnVersionWarning(warnOutdatedVersion)
+	if wrap := cassetteTransport(); wrap != nil {
+		client.WrapTransport(wrap)
+	}
+	if viper.GetBool("debug") {
+		client.SetDebug(os.Stderr)
+		client.WrapTransport(func(rt http.RoundTripper) http.RoundTripper {
+			return &api.DebugTransport{Base: rt, Out: os.Stderr}
+		})
+	}
+	if viper.GetString("trace-file") != "" {
+		if traceRecorder == nil {
+			traceRecorder = api.NewHARRecorder("chrono", Version)
+		}
+		client.WrapTransport(traceRecorder.Transport)
+	}
+	return client
+}
+
+// transport is shared by all network access, so connections are reused
+var transport http.RoundTripper
+
+// httpTransport returns the transport configured by hc and the --ca-file,
+// --client-cert, --client-key and --insecure flags. Proxies are taken from
+// http.proxy or HTTPS_PROXY/NO_PROXY.
+func httpTransport(hc *config.HTTPConfig) http.RoundTripper {
+	if transport != nil {
+		return transport
+	}
+
+	opts := api.TransportOptions{
+		Proxy:              hc.Proxy,
+		CAFile:             hc.CAFile,
+		ClientCertFile:     hc.ClientCert,
+		ClientKeyFile:      hc.ClientKey,
+		InsecureSkipVerify: hc.InsecureSkipVerify || viper.GetBool("insecure"),
+	}
+	if caFile := viper.GetString("ca-file"); caFile != "" {
+		opts.CAFile = caFile
+	}
+	if viper.GetString("client-cert") != "" || viper.GetString("client-key") != "" {
+		opts.ClientCertFile = viper.GetString("client-cert")
+		opts.ClientKeyFile = viper.GetString("client-key")
+	}
+
+	t, err := api.NewTransport(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error configuring HTTP client: %v\n", err)
+		os.Exit(1)
+	}
+	if opts.InsecureSkipVerify {
+		fmt.Fprintln(os.Stderr, "Warning: TLS certificate verification is disabled. Traffic to the platform, including credentials, can be intercepted.")
+	}
+	transport = t
+	return transport
+}
+
+// httpClient returns a client for downloads outside the platform API
+func httpClient(hc *config.HTTPConfig) *http.Client {
+	return &http.Client{Transport: httpTransport(hc), Timeout: api.DefaultTimeout}
+}
+
+// cassetteTransport returns the record or replay transport selected by the
+// environment, or nil when neither is set
+func cassetteTransport() func(http.RoundTripper) http.RoundTripper {
+	record, replay := os.Getenv(config.EnvRecord), os.Getenv(config.EnvReplay)
+	if record == "" && replay == "" {
+		return nil
+	}
+	if record != "" && replay != "" {
+		fmt.Fprintf(os.Stderr, "Error: %s and %s cannot be used together\n", config.EnvRecord, config.EnvReplay)
+		os.Exit(1)
+	}
+
+	dir := record
+	if replay != "" {
+		dir = replay
+	}
+	if cassette == nil {
+		var err error
+		cassette, err = api.OpenCassette(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading cassette: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if replay != "" {
+		return cassette.Replay
+	}
+	return cassette.Record
+}
+
+// writeTrace saves the traffic recorded for --trace-file, if any
+func writeTrace() {
+	path := viper.GetString("trace-file")
+	if path == "" || traceRecorder == nil {
+		return
+	}
+	if err := traceRecorder.WriteFile(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write trace file: %v\n", err)
+		return
+	}
+	if viper.GetBool("debug") {
+		fmt.Fprintf(os.Stderr, "[debug] HTTP trace written to %s\n", path)
+	}
+}
+
+// retryPolicy returns the default retry policy with the overrides from the config file
+func retryPolicy(rc config.RetryConfig) api.RetryPolicy {
+	policy := api.DefaultRetryPolicy()
+	if rc.MaxAttempts > 0 {
+		policy.MaxAttempts = rc.MaxAttempts
+	}
+	if rc.InitialBackoff > 0 {
+		policy.InitialBackoff = rc.InitialBackoff
+	}
+	if rc.MaxBackoff > 0 {
+		policy.MaxBackoff = rc.MaxBackoff
+	}
+	if rc.MaxElapsed > 0 {
+		policy.MaxElapsed = rc.MaxElapsed
+	}
+	return policy
 }
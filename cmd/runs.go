@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runsJSON     bool
+	runsList     listFlags
+	runsPipeline string
+	runsStatus   string
+	runsStage    string
+)
+
+// runsCmd represents the runs command
+var runsCmd = &cobra.Command{
+	Use:     "runs",
+	Aliases: []string{"run"},
+	Short:   "Inspect and control pipeline runs",
+	Long: `List, inspect, follow and cancel pipeline runs, and read their build logs.
+
+A run builds and deploys one commit of a pipeline. Start one with
+'chrono deploy'.`,
+}
+
+var runsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List runs, most recent first",
+	Long: `List runs, most recent first.
+
+Examples:
+  chrono runs list
+  chrono runs list --pipeline shop-main --status failed`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runRunsList,
+}
+
+var runsGetCmd = &cobra.Command{
+	Use:          "get <run-id>",
+	Short:        "Show the status and stages of a run",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRunsGet,
+}
+
+var runsWatchCmd = &cobra.Command{
+	Use:   "watch <run-id>",
+	Short: "Follow a run until it finishes",
+	Long: `Follow the stages of a run until it finishes.
+
+Exits with code 11 when the run fails or is cancelled. Stopping the command
+does not stop the run; use 'chrono runs cancel' for that.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRunsWatch,
+}
+
+var runsCancelCmd = &cobra.Command{
+	Use:          "cancel <run-id>",
+	Short:        "Cancel a pending or running run",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRunsCancel,
+}
+
+var runsLogsCmd = &cobra.Command{
+	Use:   "logs <run-id>",
+	Short: "Show the build logs of a run",
+	Long: `Show the logs of the stages of a run.
+
+Examples:
+  chrono runs logs run-42
+  chrono runs logs run-42 --stage build`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRunsLogs,
+}
+
+func init() {
+	rootCmd.AddCommand(runsCmd)
+	runsCmd.AddCommand(runsListCmd)
+	runsCmd.AddCommand(runsGetCmd)
+	runsCmd.AddCommand(runsWatchCmd)
+	runsCmd.AddCommand(runsCancelCmd)
+	runsCmd.AddCommand(runsLogsCmd)
+
+	runsListCmd.Flags().StringVar(&runsPipeline, "pipeline", "", "only list runs of this pipeline (name or ID)")
+	runsListCmd.Flags().StringVar(&runsStatus, "status", "", "only list runs with this status (pending, running, succeeded, failed, cancelled)")
+	runsListCmd.Flags().BoolVar(&runsJSON, "json", false, "Output as JSON")
+	runsList.register(runsListCmd)
+
+	runsGetCmd.Flags().BoolVar(&runsJSON, "json", false, "Output as JSON")
+	runsCancelCmd.Flags().BoolVar(&runsJSON, "json", false, "Output as JSON")
+	runsLogsCmd.Flags().StringVar(&runsStage, "stage", "", "only show the log of this stage (e.g. build)")
+	runsLogsCmd.Flags().BoolVar(&runsJSON, "json", false, "Output as JSON")
+}
+
+func runRunsList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+	opts, err := runsList.options()
+	if err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	filter := api.RunFilter{Status: runsStatus}
+	if runsPipeline != "" {
+		p, err := resolvePipeline(ctx, client, runsPipeline)
+		if err != nil {
+			return err
+		}
+		filter.PipelineID = p.ID
+	}
+
+	it := client.IterRuns(filter, opts)
+	header := []string{"ID", "#", "PIPELINE", "STATUS", "BRANCH", "COMMIT", "TRIGGERED BY", "CREATED", "DURATION"}
+	count, err := printList(ctx, it, runsJSON, header, runRow)
+	if err != nil {
+		return fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	if count == 0 && !runsJSON {
+		fmt.Println("No runs found. Start one with 'chrono deploy'.")
+		return nil
+	}
+	runsList.printTruncated(count, it.Total(), "runs")
+	return nil
+}
+
+// runRow returns the table columns of a run
+func runRow(run *api.Run) []string {
+	duration := "-"
+	if d := runDuration(run); d > 0 {
+		duration = formatDuration(d)
+	}
+	return []string{
+		run.ID,
+		strconv.Itoa(run.Number),
+		run.PipelineID,
+		run.Status,
+		run.Branch,
+		valueOrDash(shortSHA(run.CommitSHA)),
+		valueOrDash(run.TriggeredBy),
+		formatAgo(run.CreatedAt),
+		duration,
+	}
+}
+
+// shortSHA abbreviates a commit SHA
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func runRunsGet(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	run, err := client.GetRun(cmd.Context(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get run: %w", err)
+	}
+
+	if runsJSON {
+		return printJSON(run)
+	}
+	printRun(run)
+	return nil
+}
+
+// printRun prints the details of a run and the status of its stages
+func printRun(run *api.Run) {
+	fmt.Printf("Run #%d (%s) of pipeline %s\n", run.Number, run.ID, run.PipelineID)
+	fmt.Println()
+	fmt.Printf("  Status:       %s\n", run.Status)
+	fmt.Printf("  Branch:       %s\n", valueOrDash(run.Branch))
+	fmt.Printf("  Commit:       %s\n", valueOrDash(run.CommitSHA))
+	fmt.Printf("  Triggered by: %s\n", valueOrDash(run.TriggeredBy))
+	fmt.Printf("  Created:      %s\n", formatTime(run.CreatedAt))
+	if d := runDuration(run); d > 0 {
+		fmt.Printf("  Duration:     %s\n", formatDuration(d))
+	}
+	if run.Error != "" {
+		fmt.Printf("  Error:        %s\n", run.Error)
+	}
+
+	if len(run.Stages) > 0 {
+		fmt.Println()
+		fmt.Println("Stages:")
+		for _, stage := range run.Stages {
+			fmt.Println(stageLine(stage))
+		}
+	}
+}
+
+func runRunsWatch(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	run, err := client.GetRun(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get run: %w", err)
+	}
+
+	fmt.Printf("Run #%d (%s) of pipeline %s, commit %s\n", run.Number, run.ID, run.PipelineID, valueOrDash(shortSHA(run.CommitSHA)))
+	fmt.Println()
+	run, err = watchRun(ctx, client, run)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if err := checkRunResult(ctx, client, run); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Run succeeded in %s\n", formatDuration(runDuration(run)))
+	return nil
+}
+
+func runRunsCancel(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	run, err := client.CancelRun(cmd.Context(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to cancel run: %w", err)
+	}
+
+	if runsJSON {
+		return printJSON(run)
+	}
+	fmt.Printf("✓ Cancelled run #%d (%s)\n", run.Number, run.ID)
+	return nil
+}
+
+func runRunsLogs(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	logs, err := client.GetRunLogs(cmd.Context(), args[0], runsStage)
+	if err != nil {
+		return fmt.Errorf("failed to get run logs: %w", err)
+	}
+
+	if runsJSON {
+		return printJSON(logs)
+	}
+	if len(logs.Stages) == 0 {
+		if runsStage != "" {
+			return withExitCode(exitCodeNotFound, "run %s has no stage %q", args[0], runsStage)
+		}
+		fmt.Println("No logs yet.")
+		return nil
+	}
+
+	for i, stage := range logs.Stages {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("==> %s (%s)\n", stage.Name, stage.Status)
+		if log := strings.TrimRight(stage.Log, "\n"); log != "" {
+			fmt.Println(log)
+		}
+	}
+	return nil
+}
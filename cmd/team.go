@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	teamJSON  bool
+	teamClear bool
+)
+
+// teamCmd represents the team command
+var teamCmd = &cobra.Command{
+	Use:     "team",
+	Aliases: []string{"teams"},
+	Short:   "List teams and switch the active team",
+	Long: `List the teams you belong to and choose the active team.
+
+The active team is stored in the current profile. API requests are scoped to
+it, and 'chrono mcp-setup' creates team-scoped tokens for it. Without an
+active team, your personal scope is used.`,
+}
+
+var teamListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List your teams",
+	Args:    cobra.NoArgs,
+	RunE:    runTeamList,
+}
+
+var teamUseCmd = &cobra.Command{
+	Use:   "use <name-or-id>",
+	Short: "Switch the active team",
+	Long: `Switch the active team of the current profile.
+
+Example:
+  chrono team use payments
+  chrono team use --clear     # back to the personal scope`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if teamClear {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: runTeamUse,
+}
+
+var teamShowCmd = &cobra.Command{
+	Use:   "show [name-or-id]",
+	Short: "Show details of a team (default: the active team)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runTeamShow,
+}
+
+func init() {
+	rootCmd.AddCommand(teamCmd)
+	teamCmd.AddCommand(teamListCmd)
+	teamCmd.AddCommand(teamUseCmd)
+	teamCmd.AddCommand(teamShowCmd)
+
+	teamListCmd.Flags().BoolVar(&teamJSON, "json", false, "Output as JSON")
+	teamShowCmd.Flags().BoolVar(&teamJSON, "json", false, "Output as JSON")
+	teamUseCmd.Flags().BoolVar(&teamClear, "clear", false, "clear the active team and use the personal scope")
+}
+
+func runTeamList(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	resp, err := client.ListTeams(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	if teamJSON {
+		return printJSON(resp.Teams)
+	}
+
+	if len(resp.Teams) == 0 {
+		fmt.Println("You are not a member of any team.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTIVE\tNAME\tID\tROLE")
+	for _, team := range resp.Teams {
+		active := ""
+		if team.ID == cfg.Defaults.Team {
+			active = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", active, team.Name, team.ID, valueOrDash(team.Role))
+	}
+	return w.Flush()
+}
+
+func runTeamUse(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+
+	if teamClear {
+		cfg.Defaults.Team = ""
+		cfg.Defaults.TeamName = ""
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Println("✓ Cleared the active team; using your personal scope")
+		return nil
+	}
+
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	team, err := resolveTeam(cmd.Context(), client, args[0])
+	if err != nil {
+		return err
+	}
+
+	cfg.Defaults.Team = team.ID
+	cfg.Defaults.TeamName = team.Name
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✓ Switched to team %q (%s) in profile %q\n", team.Name, team.ID, cfg.ProfileName())
+	return nil
+}
+
+func runTeamShow(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+
+	teamID := cfg.Defaults.Team
+	if len(args) > 0 {
+		team, err := resolveTeam(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		teamID = team.ID
+	}
+	if teamID == "" {
+		return fmt.Errorf("no active team. Run 'chrono team use <name>' or pass a team")
+	}
+
+	team, err := client.GetTeam(ctx, teamID)
+	if err != nil {
+		return fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if teamJSON {
+		return printJSON(team)
+	}
+
+	fmt.Printf("ID:          %s\n", team.ID)
+	fmt.Printf("Name:        %s\n", team.Name)
+	if team.Description != "" {
+		fmt.Printf("Description: %s\n", team.Description)
+	}
+	fmt.Printf("Your role:   %s\n", valueOrDash(team.Role))
+	if team.MemberCount > 0 {
+		fmt.Printf("Members:     %d\n", team.MemberCount)
+	}
+	fmt.Printf("Active:      %t\n", team.ID == cfg.Defaults.Team)
+	return nil
+}
+
+// resolveTeam finds one of the user's teams by ID or case-insensitive name
+func resolveTeam(ctx context.Context, client *api.Client, ref string) (*api.Team, error) {
+	resp, err := client.ListTeams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	var matches []*api.Team
+	for _, team := range resp.Teams {
+		if team.ID == ref {
+			return team, nil
+		}
+		if strings.EqualFold(team.Name, ref) {
+			matches = append(matches, team)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("team %q not found. Run 'chrono team list' to see your teams", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("team name %q is ambiguous; use the team ID", ref)
+	}
+}
+
+// teamDisplayName formats a team for display, preferring its name
+func teamDisplayName(id, name string) string {
+	if name == "" {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", name, id)
+}
@@ -0,0 +1,429 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tokenName      string
+	tokenScope     string
+	tokenTeam      string
+	tokenExpiresIn string
+	tokenJSON      bool
+	tokenList      listFlags
+
+	revokeNamePattern string
+	revokeOlderThan   string
+	revokeExpired     bool
+	revokeDryRun      bool
+	revokeYes         bool
+)
+
+// tokenCmd represents the token command
+var tokenCmd = &cobra.Command{
+	Use:     "token",
+	Aliases: []string{"tokens", "api-token"},
+	Short:   "Manage API tokens",
+	Long: `Create, list, inspect and revoke API tokens for the Developer Platform.
+
+API tokens are long-lived credentials for AI editors, scripts and CI jobs.`,
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new API token",
+	Long: `Create a new API token.
+
+The token value is shown only once. Store it somewhere safe.
+
+Examples:
+  chrono token create --name "CI deploy" --expires-in 30d
+  chrono token create --name "Team bot" --scope team --team <team-id>`,
+	Args: cobra.NoArgs,
+	RunE: runTokenCreate,
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List API tokens",
+	Long: `List API tokens.
+
+The first 50 tokens are shown; use --limit or --all to list more. Results
+are printed as they are fetched.`,
+	Args: cobra.NoArgs,
+	RunE: runTokenList,
+}
+
+var tokenShowCmd = &cobra.Command{
+	Use:   "show <token-id>",
+	Short: "Show details of an API token",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTokenShow,
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke [token-id...]",
+	Short: "Revoke API tokens",
+	Long: `Revoke one or more API tokens by ID, or in bulk by name pattern or age.
+
+Examples:
+  chrono token revoke 3f2c9a1e
+  chrono token revoke --name "AI Editor MCP" --older-than 30d
+  chrono token revoke --name "CI *" --dry-run
+  chrono token revoke --expired --yes`,
+	RunE: runTokenRevoke,
+}
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+	tokenCmd.AddCommand(tokenCreateCmd)
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenCmd.AddCommand(tokenShowCmd)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+
+	tokenCreateCmd.Flags().StringVar(&tokenName, "name", "", "token name (required)")
+	tokenCreateCmd.Flags().StringVar(&tokenScope, "scope", "personal", "token scope (personal, team)")
+	tokenCreateCmd.Flags().StringVar(&tokenTeam, "team", "", "team ID for team-scoped tokens (default: the active team)")
+	tokenCreateCmd.Flags().StringVar(&tokenExpiresIn, "expires-in", "90d", "token lifetime (e.g. 12h, 30d, 52w)")
+	tokenCreateCmd.Flags().BoolVar(&tokenJSON, "json", false, "Output as JSON")
+	tokenCreateCmd.MarkFlagRequired("name")
+
+	tokenListCmd.Flags().BoolVar(&tokenJSON, "json", false, "Output as JSON")
+	tokenList.register(tokenListCmd)
+	tokenShowCmd.Flags().BoolVar(&tokenJSON, "json", false, "Output as JSON")
+
+	tokenRevokeCmd.Flags().StringVar(&revokeNamePattern, "name", "", "revoke tokens whose name matches this pattern (supports * and ?)")
+	tokenRevokeCmd.Flags().StringVar(&revokeOlderThan, "older-than", "", "revoke tokens created longer ago than this (e.g. 30d)")
+	tokenRevokeCmd.Flags().BoolVar(&revokeExpired, "expired", false, "revoke tokens that have already expired")
+	tokenRevokeCmd.Flags().BoolVar(&revokeDryRun, "dry-run", false, "show which tokens would be revoked")
+	tokenRevokeCmd.Flags().BoolVarP(&revokeYes, "yes", "y", false, "skip confirmation")
+}
+
+func runTokenCreate(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	if tokenScope != "personal" && tokenScope != "team" {
+		return fmt.Errorf("invalid scope %q: must be personal or team", tokenScope)
+	}
+	if tokenScope == "team" && tokenTeam == "" {
+		tokenTeam = cfg.Defaults.Team
+	}
+	if tokenScope == "team" && tokenTeam == "" {
+		return fmt.Errorf("--team is required for team-scoped tokens when no team is active")
+	}
+
+	expiresIn, err := parseDuration(tokenExpiresIn)
+	if err != nil {
+		return fmt.Errorf("invalid --expires-in: %w", err)
+	}
+
+	client := GetAPIClient(cfg)
+	resp, err := client.CreateToken(cmd.Context(), &api.CreateAPITokenRequest{
+		Name:      tokenName,
+		Scope:     tokenScope,
+		TeamID:    tokenTeam,
+		ExpiresIn: int(expiresIn.Seconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	if tokenJSON {
+		return printJSON(resp)
+	}
+
+	fmt.Println("✓ API token created")
+	fmt.Println()
+	fmt.Printf("  ID:      %s\n", resp.ID)
+	fmt.Printf("  Name:    %s\n", resp.Name)
+	fmt.Printf("  Scope:   %s\n", formatScope(resp.Scope, resp.TeamID))
+	fmt.Printf("  Expires: %s\n", formatTime(resp.ExpiresAt))
+	fmt.Println()
+	fmt.Printf("  Token:   \033[1m%s\033[0m\n", resp.Token)
+	fmt.Println()
+	fmt.Println("⚠️  This is the only time the token is shown. Store it somewhere safe.")
+	return nil
+}
+
+func runTokenList(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	opts, err := tokenList.options()
+	if err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	it := client.IterTokens(opts)
+	count, err := printList(cmd.Context(), it, tokenJSON, tokenTableHeader, tokenRow)
+	if err != nil {
+		return fmt.Errorf("failed to list API tokens: %w", err)
+	}
+
+	if count == 0 && !tokenJSON {
+		fmt.Println("No API tokens found.")
+		return nil
+	}
+	tokenList.printTruncated(count, it.Total(), "API tokens")
+	return nil
+}
+
+func runTokenShow(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+	token, err := client.GetToken(cmd.Context(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	if tokenJSON {
+		return printJSON(token)
+	}
+
+	fmt.Printf("ID:         %s\n", token.ID)
+	fmt.Printf("Name:       %s\n", token.Name)
+	fmt.Printf("Prefix:     %s\n", token.TokenPrefix)
+	fmt.Printf("Scope:      %s\n", formatScope(token.Scope, token.TeamID))
+	fmt.Printf("Role:       %s\n", token.Role)
+	if len(token.Teams) > 0 {
+		fmt.Printf("Teams:      %s\n", strings.Join(token.Teams, ", "))
+	}
+	fmt.Printf("Created:    %s\n", formatTime(token.CreatedAt))
+	fmt.Printf("Last used:  %s\n", formatLastUsed(token.LastUsedAt))
+	fmt.Printf("Expires:    %s\n", formatTime(token.ExpiresAt))
+	return nil
+}
+
+func runTokenRevoke(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	bulk := revokeNamePattern != "" || revokeOlderThan != "" || revokeExpired
+	if len(args) == 0 && !bulk {
+		return fmt.Errorf("specify token IDs or a filter (--name, --older-than, --expired)")
+	}
+	if len(args) > 0 && bulk {
+		return fmt.Errorf("token IDs cannot be combined with --name, --older-than or --expired")
+	}
+
+	cfg := GetConfig()
+	if err := requireSession(cfg); err != nil {
+		return err
+	}
+
+	client := GetAPIClient(cfg)
+
+	// Revoke explicit IDs directly
+	if len(args) > 0 {
+		return revokeTokens(ctx, client, args)
+	}
+
+	var olderThan time.Duration
+	if revokeOlderThan != "" {
+		var err error
+		if olderThan, err = parseDuration(revokeOlderThan); err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+	}
+	if revokeNamePattern != "" {
+		if _, err := filepath.Match(revokeNamePattern, ""); err != nil {
+			return fmt.Errorf("invalid --name pattern: %w", err)
+		}
+	}
+
+	resp, err := client.ListTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list API tokens: %w", err)
+	}
+
+	var matches []*api.APITokenResponse
+	for _, token := range resp.Tokens {
+		if revokeNamePattern != "" {
+			if ok, _ := filepath.Match(revokeNamePattern, token.Name); !ok {
+				continue
+			}
+		}
+		if olderThan > 0 && time.Since(token.CreatedAt) < olderThan {
+			continue
+		}
+		if revokeExpired && (token.ExpiresAt.IsZero() || token.ExpiresAt.After(time.Now())) {
+			continue
+		}
+		matches = append(matches, token)
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No API tokens match the given filters.")
+		return nil
+	}
+
+	printTokenTable(matches)
+	fmt.Println()
+
+	if revokeDryRun {
+		fmt.Printf("Dry run: %d token(s) would be revoked.\n", len(matches))
+		return nil
+	}
+
+	if !revokeYes {
+		prompt := promptui.Prompt{
+			Label:     fmt.Sprintf("Revoke %d token(s)", len(matches)),
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	ids := make([]string, 0, len(matches))
+	for _, token := range matches {
+		ids = append(ids, token.ID)
+	}
+	return revokeTokens(ctx, client, ids)
+}
+
+// revokeTokens revokes each token and reports the outcome
+func revokeTokens(ctx context.Context, client *api.Client, ids []string) error {
+	var failed int
+	for _, id := range ids {
+		if err := client.RevokeToken(ctx, id); err != nil {
+			fmt.Printf("✗ %s: %v\n", id, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Revoked %s\n", id)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to revoke %d of %d token(s)", failed, len(ids))
+	}
+	return nil
+}
+
+// tokenTableHeader is the header of API token tables
+var tokenTableHeader = []string{"ID", "NAME", "PREFIX", "SCOPE", "CREATED", "LAST USED", "EXPIRES"}
+
+// printTokenTable prints API tokens as a table
+func printTokenTable(tokens []*api.APITokenResponse) {
+	table := newTableWriter(tokenTableHeader...)
+	for _, token := range tokens {
+		table.Row(tokenRow(token)...)
+	}
+	table.Flush()
+}
+
+// tokenRow returns the table columns of an API token
+func tokenRow(token *api.APITokenResponse) []string {
+	return []string{
+		token.ID,
+		token.Name,
+		token.TokenPrefix,
+		formatScope(token.Scope, token.TeamID),
+		formatTime(token.CreatedAt),
+		formatLastUsed(token.LastUsedAt),
+		formatExpiry(token.ExpiresAt),
+	}
+}
+
+// formatScope formats a token scope, including the team for team-scoped tokens
+func formatScope(scope, teamID string) string {
+	if teamID != "" {
+		return fmt.Sprintf("%s (%s)", scope, teamID)
+	}
+	return scope
+}
+
+// formatTime formats a timestamp for display
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// formatLastUsed formats a token's last-used time relative to now
+func formatLastUsed(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "never"
+	}
+	return formatAgo(*t)
+}
+
+// formatExpiry formats a token's expiry, highlighting expired tokens
+func formatExpiry(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	if t.Before(time.Now()) {
+		return "expired"
+	}
+	return formatTime(t)
+}
+
+// formatAgo formats a past time as a human-readable age (e.g. "3d ago")
+func formatAgo(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// parseDuration parses a duration, additionally accepting days (d) and weeks (w)
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			value, err := strconv.Atoi(n)
+			if err != nil || value <= 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(value) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive: %q", s)
+	}
+	return d, nil
+}
+
+// printJSON prints a value as indented JSON
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
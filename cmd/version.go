@@ -1,6 +1,15 @@
 package cmd
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/spf13/cobra"
+)
 
 // Version information (set by main.go build flags)
 var (
@@ -9,6 +18,9 @@ var (
 	Commit    = "unknown"
 )
 
+// upgradeCommand installs the latest release
+const upgradeCommand = "curl -sSL https://raw.githubusercontent.com/ChronoAIProject/chrono-cli/main/install.sh | sh"
+
 // GetFullVersion returns the full version string with build info
 func GetFullVersion() string {
 	version := Version
@@ -20,3 +32,150 @@ func GetFullVersion() string {
 	}
 	return version
 }
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show the CLI version and, with --server, the platform's compatibility",
+	Long: `Show the version of the CLI and how it was built.
+
+With --server, the platform is asked which CLI versions it supports. The
+platform may recommend a newer version, or refuse requests from versions
+older than its minimum; commands then fail with exit code 10.
+
+Example:
+  chrono version
+  chrono version --server --json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runVersion,
+}
+
+var (
+	versionServer bool
+	versionJSON   bool
+)
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().BoolVar(&versionServer, "server", false, "also query the platform version and supported CLI versions")
+	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "output as JSON")
+}
+
+// versionOutput is the JSON form of the version command
+type versionOutput struct {
+	Version   string         `json:"version"`
+	Commit    string         `json:"commit"`
+	BuildTime string         `json:"build_time"`
+	GoVersion string         `json:"go_version"`
+	Platform  string         `json:"platform"`
+	Server    *serverVersion `json:"server,omitempty"`
+}
+
+// serverVersion describes the platform and the CLI versions it supports
+type serverVersion struct {
+	URL                   string `json:"url"`
+	Name                  string `json:"name,omitempty"`
+	Version               string `json:"version,omitempty"`
+	MinCLIVersion         string `json:"min_cli_version,omitempty"`
+	RecommendedCLIVersion string `json:"recommended_cli_version,omitempty"`
+	Status                string `json:"status"`
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	out := versionOutput{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	var serverErr error
+	if versionServer {
+		cfg := GetConfig()
+		out.Server = &serverVersion{URL: cfg.MCP.ServerURL}
+
+		// The compatibility is reported below, so skip the usual warning
+		client := GetAPIClient(cfg)
+		client.OnVersionWarning(nil)
+		info, err := client.GetMCPInfo(cmd.Context())
+		var versionErr *api.UnsupportedVersionError
+		switch {
+		case errors.As(err, &versionErr):
+			out.Server.MinCLIVersion = versionErr.MinVersion
+			out.Server.Status = "unsupported"
+			serverErr = err
+		case err != nil:
+			return fmt.Errorf("failed to query platform version: %w", err)
+		default:
+			out.Server.Name = info.Name
+			out.Server.Version = info.Version
+			out.Server.MinCLIVersion = info.MinCLIVersion
+			out.Server.RecommendedCLIVersion = info.RecommendedCLIVersion
+			out.Server.Status = versionStatus(info.Compatibility().Check(Version))
+		}
+	}
+
+	if versionJSON {
+		if err := printJSON(out); err != nil {
+			return err
+		}
+		return serverErr
+	}
+
+	printVersion(out)
+	return serverErr
+}
+
+// printVersion prints the CLI build and the platform compatibility
+func printVersion(out versionOutput) {
+	fmt.Printf("chrono %s\n", out.Version)
+	fmt.Println()
+	fmt.Printf("  Commit:      %s\n", out.Commit)
+	fmt.Printf("  Built at:    %s\n", out.BuildTime)
+	fmt.Printf("  Go:          %s\n", out.GoVersion)
+	fmt.Printf("  Platform:    %s\n", out.Platform)
+
+	if out.Server == nil {
+		return
+	}
+	fmt.Println()
+	fmt.Printf("Server %s:\n", out.Server.URL)
+	if out.Server.Name != "" {
+		fmt.Printf("  Name:        %s\n", out.Server.Name)
+		fmt.Printf("  Version:     %s\n", valueOrDash(out.Server.Version))
+	}
+	fmt.Printf("  Minimum CLI: %s\n", valueOrDash(out.Server.MinCLIVersion))
+	if out.Server.Name != "" {
+		fmt.Printf("  Latest CLI:  %s\n", valueOrDash(out.Server.RecommendedCLIVersion))
+	}
+	fmt.Printf("  Status:      %s\n", out.Server.Status)
+	if out.Server.Status == "outdated" {
+		fmt.Println()
+		fmt.Printf("Upgrade with: %s\n", upgradeCommand)
+	}
+}
+
+// versionStatus returns the display name of a compatibility check result
+func versionStatus(status api.VersionStatus) string {
+	switch status {
+	case api.VersionSupported:
+		return "supported"
+	case api.VersionOutdated:
+		return "outdated"
+	case api.VersionUnsupported:
+		return "unsupported"
+	}
+	return "unknown"
+}
+
+// versionWarning ensures the upgrade notice is printed once per run
+var versionWarning sync.Once
+
+// warnOutdatedVersion tells the user a newer CLI version is recommended
+func warnOutdatedVersion(version string, compat api.Compatibility) {
+	versionWarning.Do(func() {
+		fmt.Fprintf(os.Stderr, "Warning: chrono %s is outdated; the platform recommends %s or later.\n", version, compat.RecommendedVersion)
+		fmt.Fprintf(os.Stderr, "Upgrade with: %s\n", upgradeCommand)
+	})
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+)
+
+// runPollInterval is how often the status of a run is fetched while watching it
+const runPollInterval = 3 * time.Second
+
+// failedLogLines is the number of log lines shown when a run fails
+const failedLogLines = 20
+
+// stageIcons are the symbols of stage states in the run view
+var stageIcons = map[string]string{
+	api.StatusPending:   "○",
+	api.StatusRunning:   "●",
+	api.StatusSucceeded: "✓",
+	api.StatusFailed:    "✗",
+	api.StatusCancelled: "✗",
+	api.StatusSkipped:   "-",
+}
+
+// watchRun polls a run until it finishes, showing the progress of its
+// stages, and returns the finished run
+func watchRun(ctx context.Context, client *api.Client, run *api.Run) (*api.Run, error) {
+	view := newRunView(os.Stdout)
+	view.Update(run)
+
+	ticker := time.NewTicker(runPollInterval)
+	defer ticker.Stop()
+	for !run.Finished() {
+		select {
+		case <-ctx.Done():
+			return run, stoppedWatching(run, ctx.Err())
+		case <-ticker.C:
+		}
+
+		latest, err := client.GetRun(ctx, run.ID)
+		if err != nil {
+			return run, stoppedWatching(run, fmt.Errorf("failed to get run status: %w", err))
+		}
+		run = latest
+		view.Update(run)
+	}
+	return run, nil
+}
+
+// stoppedWatching explains that a run goes on when the user stops watching it
+func stoppedWatching(run *api.Run, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("stopped watching run %s; it keeps running on the platform: %w", run.ID, context.Canceled)
+	}
+	return err
+}
+
+// runView renders the stages of a run. On a terminal the stage lines are
+// redrawn in place; otherwise a line is printed whenever a stage changes.
+type runView struct {
+	out    io.Writer
+	live   bool
+	lines  int               // stage lines drawn by the last live update
+	states map[string]string // last printed status of each stage
+}
+
+func newRunView(out io.Writer) *runView {
+	f, ok := out.(*os.File)
+	return &runView{out: out, live: ok && isTerminal(f), states: map[string]string{}}
+}
+
+// Update shows the current state of the run
+func (v *runView) Update(run *api.Run) {
+	if v.live {
+		if v.lines > 0 {
+			fmt.Fprintf(v.out, "\033[%dA", v.lines)
+		}
+		for _, stage := range run.Stages {
+			fmt.Fprintf(v.out, "\033[2K%s\n", stageLine(stage))
+		}
+		v.lines = len(run.Stages)
+		return
+	}
+
+	for _, stage := range run.Stages {
+		if v.states[stage.Name] == stage.Status {
+			continue
+		}
+		v.states[stage.Name] = stage.Status
+		if stage.Status != api.StatusPending {
+			fmt.Fprintln(v.out, stageLine(stage))
+		}
+	}
+}
+
+// stageLine formats a stage with its status and duration
+func stageLine(stage api.Stage) string {
+	icon := stageIcons[stage.Status]
+	if icon == "" {
+		icon = "?"
+	}
+	line := fmt.Sprintf("  %s %-8s %s", icon, stage.Name, stage.Status)
+	if d := stageDuration(stage); d > 0 {
+		line += " (" + formatDuration(d) + ")"
+	}
+	return line
+}
+
+// stageDuration returns how long a stage ran, or has been running so far
+func stageDuration(stage api.Stage) time.Duration {
+	if stage.StartedAt == nil {
+		return 0
+	}
+	if stage.FinishedAt != nil {
+		return stage.FinishedAt.Sub(*stage.StartedAt)
+	}
+	return time.Since(*stage.StartedAt)
+}
+
+// runDuration returns how long a run took, or has been running so far
+func runDuration(run *api.Run) time.Duration {
+	if run.StartedAt == nil {
+		return 0
+	}
+	if run.FinishedAt != nil {
+		return run.FinishedAt.Sub(*run.StartedAt)
+	}
+	return time.Since(*run.StartedAt)
+}
+
+// formatDuration formats a duration rounded to seconds, e.g. "1m12s"
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return "<1s"
+	}
+	return d.Round(time.Second).String()
+}
+
+// checkRunResult returns an error when a finished run did not succeed,
+// printing why it failed and the end of the log of the failed stage
+func checkRunResult(ctx context.Context, client *api.Client, run *api.Run) error {
+	switch run.Status {
+	case api.StatusSucceeded:
+		return nil
+	case api.StatusCancelled:
+		return withExitCode(exitCodeRunFailed, "run %s was cancelled", run.ID)
+	}
+
+	if run.Error != "" {
+		fmt.Printf("✗ Run failed: %s\n", run.Error)
+	} else {
+		fmt.Println("✗ Run failed")
+	}
+	printFailedStageLog(ctx, client, run, failedLogLines)
+	if stage := failedStage(run); stage != nil {
+		return withExitCode(exitCodeRunFailed, "run %s failed at stage %s. See the full log with 'chrono runs logs %s'", run.ID, stage.Name, run.ID)
+	}
+	return withExitCode(exitCodeRunFailed, "run %s failed", run.ID)
+}
+
+// failedStage returns the stage a run failed at, or nil
+func failedStage(run *api.Run) *api.Stage {
+	for i := range run.Stages {
+		if run.Stages[i].Status == api.StatusFailed {
+			return &run.Stages[i]
+		}
+	}
+	return nil
+}
+
+// printFailedStageLog prints the last lines of the log of the stage a run
+// failed at
+func printFailedStageLog(ctx context.Context, client *api.Client, run *api.Run, maxLines int) {
+	stage := failedStage(run)
+	if stage == nil {
+		return
+	}
+	logs, err := client.GetRunLogs(ctx, run.ID, stage.Name)
+	if err != nil || len(logs.Stages) == 0 {
+		return
+	}
+
+	lines := strings.Split(strings.TrimRight(logs.Stages[0].Log, "\n"), "\n")
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	fmt.Println()
+	fmt.Printf("Last lines of the %s log:\n", stage.Name)
+	for _, line := range lines {
+		fmt.Printf("  %s\n", line)
+	}
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/ChronoAIProject/chrono-cli/pkg/auth"
+	"github.com/spf13/cobra"
+)
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the authenticated user and validate the session with the server",
+	Long: `Validate the current session with the platform and show who you are logged in as.
+
+Unlike 'chrono status', which only reads the local config, whoami calls the
+server, so a revoked or expired session is detected. Claims of the access
+token (issuer, audience, roles, expiry) are decoded locally for display.
+
+Exit status:
+  0  the session is valid
+  3  not logged in, or the server rejected the session
+  other  the session could not be verified (see exit codes in the README)`,
+	SilenceUsage: true,
+	RunE:         runWhoami,
+}
+
+var whoamiJSON bool
+
+func init() {
+	rootCmd.AddCommand(whoamiCmd)
+	whoamiCmd.Flags().BoolVar(&whoamiJSON, "json", false, "output as JSON")
+}
+
+// whoamiOutput is the JSON form of the whoami command
+type whoamiOutput struct {
+	Profile     string       `json:"profile"`
+	Server      string       `json:"server"`
+	Credentials string       `json:"credentials"`
+	User        *api.User    `json:"user"`
+	Claims      *auth.Claims `json:"claims,omitempty"`
+}
+
+func runWhoami(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+
+	if !cfg.HasValidSession() {
+		return withExitCode(exitCodeNoSession, "not logged in. Run 'chrono login' first")
+	}
+
+	// The client refreshes an expiring session before calling the server,
+	// so the claims below are decoded from the token that was just accepted
+	client := GetAPIClient(cfg)
+	user, err := client.GetCurrentUser(cmd.Context())
+	if err != nil {
+		if api.IsUnauthorized(err) {
+			return withExitCode(exitCodeNoSession, "session is not valid: %w", err)
+		}
+		return fmt.Errorf("failed to verify session: %w", err)
+	}
+
+	out := whoamiOutput{
+		Profile:     cfg.ProfileName(),
+		Server:      cfg.MCP.ServerURL,
+		Credentials: cfg.CredentialSource(),
+		User:        user,
+	}
+	if auth.IsJWT(cfg.Auth.AccessToken) {
+		claims, err := auth.ParseClaims(cfg.Auth.AccessToken)
+		if err != nil {
+			return fmt.Errorf("failed to inspect access token: %w", err)
+		}
+		out.Claims = claims
+	}
+
+	if whoamiJSON {
+		return printJSON(out)
+	}
+
+	printWhoami(out)
+	return nil
+}
+
+// printWhoami prints the user, token claims and teams
+func printWhoami(out whoamiOutput) {
+	fmt.Printf("Logged in to %s as \033[1m%s\033[0m\n", out.Server, displayName(out.User))
+	fmt.Println()
+	fmt.Printf("  Profile:     %s\n", out.Profile)
+	fmt.Printf("  Credentials: %s\n", out.Credentials)
+	fmt.Printf("  User ID:     %s\n", out.User.ID)
+	if out.User.Name != "" {
+		fmt.Printf("  Name:        %s\n", out.User.Name)
+	}
+	if out.User.Role != "" {
+		fmt.Printf("  Role:        %s\n", out.User.Role)
+	}
+
+	fmt.Println()
+	if out.Claims == nil {
+		fmt.Println("Token: API token (no claims to inspect)")
+	} else {
+		fmt.Println("Token:")
+		fmt.Printf("  Issuer:      %s\n", valueOrDash(out.Claims.Issuer))
+		fmt.Printf("  Audience:    %s\n", valueOrDash(strings.Join(out.Claims.Audience, ", ")))
+		fmt.Printf("  Roles:       %s\n", valueOrDash(strings.Join(out.Claims.Roles, ", ")))
+		if !out.Claims.ExpiresAt.IsZero() {
+			fmt.Printf("  Expires:     %s (in %s)\n",
+				out.Claims.ExpiresAt.Local().Format(time.RFC3339),
+				time.Until(out.Claims.ExpiresAt).Round(time.Second))
+		}
+	}
+
+	fmt.Println()
+	if len(out.User.Teams) == 0 {
+		fmt.Println("Teams: none")
+		return
+	}
+	fmt.Println("Teams:")
+	for _, team := range out.User.Teams {
+		role := ""
+		if team.Role != "" {
+			role = fmt.Sprintf(" (%s)", team.Role)
+		}
+		fmt.Printf("  %s%s  %s\n", team.Name, role, team.ID)
+	}
+}
+
+// displayName returns the best human-readable identifier for a user
+func displayName(user *api.User) string {
+	if user.Email != "" {
+		return user.Email
+	}
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.ID
+}
+
+// valueOrDash returns "-" for empty values in tabular output
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Cassette records API interactions to a directory and replays them, so
+// commands can be tested against real platform responses without a server.
+//
+// Each interaction is stored as a numbered JSON file with secrets redacted.
+// Replayed requests are matched on method, path, query and body; identical
+// requests (e.g. device flow polls) are served in recorded order.
+type Cassette struct {
+	Dir string
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+	next         int
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the redacted part of a request used for matching
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a redacted response
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// ErrUnmatchedRequest is returned when replaying a request that is not in the cassette
+var ErrUnmatchedRequest = errors.New("no recorded interaction")
+
+// cassetteFileName matches interaction files, e.g. 0001-GET-auth-me.json
+var cassetteFileName = regexp.MustCompile(`^\d+-.*\.json$`)
+
+// OpenCassette loads the interactions recorded in dir, creating it if needed.
+// New recordings are numbered after the existing ones.
+func OpenCassette(dir string) (*Cassette, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && cassetteFileName.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	c := &Cassette{Dir: dir}
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read interaction: %w", err)
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(data, &interaction); err != nil {
+			return nil, fmt.Errorf("failed to parse interaction %s: %w", name, err)
+		}
+		c.interactions = append(c.interactions, &interaction)
+	}
+	c.used = make([]bool, len(c.interactions))
+	c.next = len(c.interactions)
+	return c, nil
+}
+
+// Len returns the number of interactions in the cassette
+func (c *Cassette) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.interactions)
+}
+
+// Record returns a round tripper that sends requests through base and saves
+// each interaction to the cassette directory
+func (c *Cassette) Record(base http.RoundTripper) http.RoundTripper {
+	return &recordTransport{cassette: c, base: base}
+}
+
+// Replay returns a round tripper that serves responses from the cassette and
+// fails on requests that were not recorded. base is never used.
+func (c *Cassette) Replay(base http.RoundTripper) http.RoundTripper {
+	return &replayTransport{cassette: c}
+}
+
+// save writes an interaction to the next numbered file
+func (c *Cassette) save(interaction *Interaction) error {
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal interaction: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.next++
+	name := fmt.Sprintf("%04d-%s-%s.json", c.next, interaction.Request.Method, slug(interaction.Request.URL))
+	if err := os.WriteFile(filepath.Join(c.Dir, name), data, 0600); err != nil {
+		return fmt.Errorf("failed to write interaction: %w", err)
+	}
+	c.interactions = append(c.interactions, interaction)
+	c.used = append(c.used, true)
+	return nil
+}
+
+// match returns the first unused interaction for the request
+func (c *Cassette) match(req RecordedRequest) *Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, interaction := range c.interactions {
+		recorded := interaction.Request
+		if c.used[i] || recorded.Method != req.Method || recorded.URL != req.URL {
+			continue
+		}
+		if normalizeBody(recorded.Body) != normalizeBody(req.Body) {
+			continue
+		}
+		c.used[i] = true
+		return interaction
+	}
+	return nil
+}
+
+// recordTransport saves interactions made through base
+type recordTransport struct {
+	cassette *Cassette
+	base     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := base(t.base).RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := RedactHeaders(resp.Header)
+	header.Del("Content-Length")
+	header.Del("Date")
+	err = t.cassette.save(&Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       string(RedactBody(body)),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// replayTransport serves recorded responses
+type replayTransport struct {
+	cassette *Cassette
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := t.cassette.match(recorded)
+	if interaction == nil {
+		return nil, fmt.Errorf("%w for %s %s in %s", ErrUnmatchedRequest, recorded.Method, recorded.URL, t.cassette.Dir)
+	}
+
+	body := interaction.Response.Body
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// recordRequest returns the redacted request used for recording and matching.
+// The host is left out so cassettes replay against any server URL.
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	body, err := peekRequestBody(req)
+	if err != nil {
+		return RecordedRequest{}, err
+	}
+
+	u := *req.URL
+	u.Scheme = ""
+	u.Host = ""
+	u.User = nil
+	header := RedactHeaders(req.Header)
+	header.Del(IdempotencyKeyHeader)
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    RedactURL(&u),
+		Header: header,
+	}
+	if len(body) > 0 {
+		recorded.Body = string(RedactBody(body))
+	}
+	return recorded, nil
+}
+
+// normalizeBody re-encodes JSON bodies so formatting differences do not matter
+func normalizeBody(body string) string {
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return string(out)
+}
+
+// slug turns a URL path into a file name fragment
+func slug(rawURL string) string {
+	path, _, _ := strings.Cut(rawURL, "?")
+	s := strings.Trim(nonSlugChars.ReplaceAllString(path, "-"), "-")
+	if len(s) > 60 {
+		s = s[:60]
+	}
+	if s == "" {
+		return "root"
+	}
+	return s
+}
+
+var nonSlugChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth/me":
+			w.Write([]byte(`{"id":"user-1","email":"dev@example.com"}`))
+		case "/auth/device/poll":
+			if polls.Add(1) == 1 {
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{"status":"authorization_pending"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"jwt-secret","refresh_token":"refresh-secret"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	dir := t.TempDir()
+	cassette, err := OpenCassette(dir)
+	if err != nil {
+		t.Fatalf("OpenCassette() failed: %v", err)
+	}
+	client := NewClient(server.URL)
+	client.SetAuthToken("jwt-secret")
+	client.WrapTransport(cassette.Record)
+
+	ctx := context.Background()
+	if _, err := client.GetCurrentUser(ctx); err != nil {
+		t.Fatalf("GetCurrentUser() failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.PollDeviceFlow(ctx, "device-secret"); err != nil {
+			t.Fatalf("PollDeviceFlow() failed: %v", err)
+		}
+	}
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 {
+		t.Fatalf("Recorded %d interactions, want 3", len(files))
+	}
+	if !strings.HasSuffix(files[0], "0001-GET-auth-me.json") {
+		t.Errorf("First interaction = %s, want 0001-GET-auth-me.json", filepath.Base(files[0]))
+	}
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		for _, secret := range []string{"jwt-secret", "refresh-secret", "device-secret"} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("%s leaks %q", filepath.Base(file), secret)
+			}
+		}
+	}
+
+	// Replay against a server URL that no longer exists
+	replay, err := OpenCassette(dir)
+	if err != nil {
+		t.Fatalf("OpenCassette() failed: %v", err)
+	}
+	client = NewClient("http://replay.invalid")
+	client.WrapTransport(replay.Replay)
+
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		t.Fatalf("Replayed GetCurrentUser() failed: %v", err)
+	}
+	if user.Email != "dev@example.com" {
+		t.Errorf("Email = %v, want dev@example.com", user.Email)
+	}
+
+	first, err := client.PollDeviceFlow(ctx, "another-device-code")
+	if err != nil || first.Status != "authorization_pending" {
+		t.Errorf("First replayed poll = %+v, %v, want authorization_pending", first, err)
+	}
+	second, err := client.PollDeviceFlow(ctx, "another-device-code")
+	if err != nil {
+		t.Fatalf("Second replayed poll failed: %v", err)
+	}
+	if second.AccessToken != redacted {
+		t.Errorf("AccessToken = %v, want %v", second.AccessToken, redacted)
+	}
+
+	// Every interaction has been used
+	_, err = client.GetCurrentUser(ctx)
+	if !errors.Is(err, ErrUnmatchedRequest) {
+		t.Errorf("GetCurrentUser() error = %v, want ErrUnmatchedRequest", err)
+	}
+}
+
+func TestCassette_ReplayFailsOnUnmatchedRequest(t *testing.T) {
+	dir := t.TempDir()
+	interaction := `{
+  "request": {"method": "POST", "url": "/auth/tokens", "body": "{\"name\": \"CI\", \"scope\": \"personal\"}"},
+  "response": {"status_code": 201, "body": "{\"id\":\"tok-1\"}"}
+}`
+	if err := os.WriteFile(filepath.Join(dir, "0001-POST-auth-tokens.json"), []byte(interaction), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cassette, err := OpenCassette(dir)
+	if err != nil {
+		t.Fatalf("OpenCassette() failed: %v", err)
+	}
+	client := NewClient("http://replay.invalid")
+	client.WrapTransport(cassette.Replay)
+	ctx := context.Background()
+
+	_, err = client.CreateToken(ctx, &CreateAPITokenRequest{Name: "other", Scope: "personal"})
+	if !errors.Is(err, ErrUnmatchedRequest) {
+		t.Fatalf("CreateToken() error = %v, want ErrUnmatchedRequest", err)
+	}
+
+	resp, err := client.CreateToken(ctx, &CreateAPITokenRequest{Name: "CI", Scope: "personal"})
+	if err != nil {
+		t.Fatalf("CreateToken() failed: %v", err)
+	}
+	if resp.ID != "tok-1" {
+		t.Errorf("ID = %v, want tok-1", resp.ID)
+	}
+}
@@ -2,31 +2,105 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
 )
 
+// DefaultTimeout is the default deadline for each HTTP request
+const DefaultTimeout = 30 * time.Second
+
+// refreshSkew is how long before expiry the access token is proactively refreshed
+const refreshSkew = time.Minute
+
 // Client represents an API client for the Developer Platform
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	timeout    time.Duration
+	retry      RetryPolicy
+	debug      io.Writer
 	authToken  string
 	apiToken   string
+
+	// Session refresh state
+	refreshMu    sync.Mutex
+	refreshToken string
+	tokenExpiry  time.Time
+	onRefresh    func(*LoginResponse)
+	clientID     string
+	clientSecret string
+
+	// teamID scopes requests to a team (see SetTeam)
+	teamID string
+
+	// CLI version sent to and checked against the platform (see SetVersion)
+	version          string
+	userAgent        string
+	onVersionWarning func(version string, compat Compatibility)
+	versionWarning   sync.Once
 }
 
+// TeamHeader is the request header that selects the team a request acts on
+const TeamHeader = "X-Team-ID"
+
 // NewClient creates a new API client
 func NewClient(baseURL string) *Client {
 	return &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL:    baseURL,
+		httpClient: &http.Client{},
+		timeout:    DefaultTimeout,
+		retry:      DefaultRetryPolicy(),
+		userAgent:  UserAgent(""),
 	}
 }
 
+// SetTimeout sets the deadline for each HTTP request, in addition to any
+// deadline of the context passed to a method. Zero disables it.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+}
+
+// SetRetryPolicy sets how failed requests are retried
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// SetDebug enables debug output, such as retries, to w. A nil writer disables it.
+func (c *Client) SetDebug(w io.Writer) {
+	c.debug = w
+}
+
+// SetTransport replaces the HTTP transport, e.g. with one from NewTransport.
+// Call it before WrapTransport.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// WrapTransport wraps the HTTP transport, e.g. to log or record traffic
+func (c *Client) WrapTransport(wrap func(http.RoundTripper) http.RoundTripper) {
+	c.httpClient.Transport = wrap(base(c.httpClient.Transport))
+}
+
+// SetVersion sets the CLI version sent in the User-Agent header. Responses
+// are checked against the versions the platform advertises: requests fail
+// with *UnsupportedVersionError below the minimum version.
+func (c *Client) SetVersion(version string) {
+	c.version = version
+	c.userAgent = UserAgent(version)
+}
+
+// OnVersionWarning registers a callback invoked once when the platform
+// recommends a newer CLI version
+func (c *Client) OnVersionWarning(fn func(version string, compat Compatibility)) {
+	c.onVersionWarning = fn
+}
+
 // SetAuthToken sets the JWT authentication token
 func (c *Client) SetAuthToken(token string) {
 	c.authToken = token
@@ -37,66 +111,266 @@ func (c *Client) SetAPIToken(token string) {
 	c.apiToken = token
 }
 
-// Do performs an HTTP request with authentication
-func (c *Client) Do(method, path string, body interface{}, response interface{}) error {
-	var bodyReader io.Reader
+// SetRefreshToken sets the refresh token used to renew the JWT when it expires
+func (c *Client) SetRefreshToken(token string) {
+	c.refreshToken = token
+}
+
+// SetClientCredentials sets service account credentials used to obtain
+// a JWT with the client credentials grant when none is available
+func (c *Client) SetClientCredentials(clientID, clientSecret string) {
+	c.clientID = clientID
+	c.clientSecret = clientSecret
+}
+
+// SetTokenExpiry sets the expiry time of the JWT authentication token
+func (c *Client) SetTokenExpiry(expiry time.Time) {
+	c.tokenExpiry = expiry
+}
+
+// SetTeam sets the team authenticated requests act on. An empty ID uses the
+// user's personal scope.
+func (c *Client) SetTeam(teamID string) {
+	c.teamID = teamID
+}
+
+// OnTokenRefresh registers a callback invoked after the session has been
+// refreshed, so the caller can persist the rotated credentials
+func (c *Client) OnTokenRefresh(fn func(*LoginResponse)) {
+	c.onRefresh = fn
+}
+
+// Do performs an HTTP request with authentication.
+// When a refresh token is available, the JWT is refreshed shortly before it
+// expires and the request is retried once if the server rejects the token.
+func (c *Client) Do(ctx context.Context, method, path string, body interface{}, response interface{}) error {
+	if c.canRefresh() && c.needsRefresh() {
+		if err := c.refreshSession(ctx); err != nil && c.isTokenExpired() {
+			return fmt.Errorf("session expired and could not be refreshed: %w", err)
+		}
+	}
+
+	status, err := c.do(ctx, method, path, body, response, true)
+	if status == http.StatusUnauthorized && c.canRefresh() {
+		if refreshErr := c.refreshSession(ctx); refreshErr != nil {
+			return err
+		}
+		_, err = c.do(ctx, method, path, body, response, true)
+	}
+
+	return err
+}
+
+// do performs a single HTTP request and returns the response status code
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, response interface{}, authenticated bool) (int, error) {
+	status, header, respBody, err := c.doRaw(ctx, method, path, body, authenticated)
+	if err != nil {
+		return status, err
+	}
+
+	// Check for error status codes
+	if status >= 400 {
+		return status, newError(status, header, respBody)
+	}
+
+	// Parse response body if provided
+	if response != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, response); err != nil {
+			return status, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+
+	return status, nil
+}
+
+// doRaw performs an HTTP request and returns the status code, headers and raw body.
+// Transient failures are retried according to the retry policy.
+func (c *Client) doRaw(ctx context.Context, method, path string, body interface{}, authenticated bool) (int, http.Header, []byte, error) {
+	var data []byte
 	if body != nil {
 		jsonData, err := json.Marshal(body)
 		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
+			return 0, nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(jsonData)
+		data = jsonData
+	}
+
+	retryable := isIdempotent(method) || idempotencyKey(ctx) != ""
+	maxAttempts := max(c.retry.MaxAttempts, 1)
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		status, header, respBody, err := c.send(ctx, method, path, data, authenticated)
+		if err == nil && !isRetryableStatus(status) {
+			return status, header, respBody, nil
+		}
+		if !retryable || attempt >= maxAttempts || ctx.Err() != nil || isPermanent(err) {
+			return status, header, respBody, err
+		}
+
+		wait := c.retry.backoff(attempt)
+		if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+			if d, ok := parseRetryAfter(header.Get("Retry-After"), time.Now()); ok {
+				wait = d
+			}
+		}
+		if c.retry.MaxElapsed > 0 && time.Since(start)+wait > c.retry.MaxElapsed {
+			return status, header, respBody, err
+		}
+
+		reason := fmt.Sprintf("status %d", status)
+		if err != nil {
+			reason = err.Error()
+		}
+		c.debugf("retrying %s %s in %s (attempt %d/%d): %s", method, path, wait.Round(time.Millisecond), attempt+1, maxAttempts, reason)
+
+		if err := SleepContext(ctx, wait); err != nil {
+			return 0, nil, nil, fmt.Errorf("failed to perform request: %w", err)
+		}
+	}
+}
+
+// send performs a single attempt of an HTTP request
+func (c *Client) send(ctx context.Context, method, path string, data []byte, authenticated bool) (int, http.Header, []byte, error) {
+	var bodyReader io.Reader
+	if data != nil {
+		bodyReader = bytes.NewReader(data)
 	}
 
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
 	url := c.baseURL + path
-	req, err := http.NewRequest(method, url, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return 0, nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if key := idempotencyKey(ctx); key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
 
 	// Prefer API token over JWT for API calls
-	if c.apiToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.apiToken)
-	} else if c.authToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	if authenticated {
+		if c.apiToken != "" {
+			req.Header.Set("Authorization", "Bearer "+c.apiToken)
+		} else if c.authToken != "" {
+			req.Header.Set("Authorization", "Bearer "+c.authToken)
+		}
+		if c.teamID != "" {
+			req.Header.Set(TeamHeader, c.teamID)
+		}
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to perform request: %w", err)
+		return 0, nil, nil, fmt.Errorf("failed to perform request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return resp.StatusCode, resp.Header, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if err := c.checkVersion(resp.StatusCode, resp.Header); err != nil {
+		return resp.StatusCode, resp.Header, respBody, err
 	}
 
-	// Check for error status codes
-	if resp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != "" {
-			return fmt.Errorf("API error (status %d): %s", resp.StatusCode, errResp.Error)
-		}
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	return resp.StatusCode, resp.Header, respBody, nil
+}
+
+// checkVersion compares the CLI version with the versions advertised in a response
+func (c *Client) checkVersion(status int, header http.Header) error {
+	compat := compatibilityFromHeader(header)
+	if status == http.StatusUpgradeRequired {
+		return &UnsupportedVersionError{Version: firstNonEmpty(c.version, "dev"), MinVersion: compat.MinVersion}
 	}
 
-	// Parse response body if provided
-	if response != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, response); err != nil {
-			return fmt.Errorf("failed to unmarshal response: %w", err)
+	switch compat.Check(c.version) {
+	case VersionUnsupported:
+		return &UnsupportedVersionError{Version: c.version, MinVersion: compat.MinVersion}
+	case VersionOutdated:
+		if c.onVersionWarning != nil {
+			c.versionWarning.Do(func() { c.onVersionWarning(c.version, compat) })
 		}
 	}
+	return nil
+}
+
+// debugf writes a debug message if debug output is enabled
+func (c *Client) debugf(format string, args ...interface{}) {
+	if c.debug != nil {
+		fmt.Fprintf(c.debug, "[debug] "+format+"\n", args...)
+	}
+}
+
+// withTimeout applies the per-request timeout to ctx
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// canRefresh reports whether the client can renew its JWT
+func (c *Client) canRefresh() bool {
+	return c.apiToken == "" && (c.refreshToken != "" || c.clientID != "")
+}
+
+// needsRefresh reports whether the JWT is missing or about to expire
+func (c *Client) needsRefresh() bool {
+	if c.authToken == "" {
+		return true
+	}
+	return !c.tokenExpiry.IsZero() && time.Until(c.tokenExpiry) < refreshSkew
+}
+
+// isTokenExpired reports whether the JWT can no longer be used
+func (c *Client) isTokenExpired() bool {
+	return c.authToken == "" || (!c.tokenExpiry.IsZero() && time.Now().After(c.tokenExpiry))
+}
+
+// refreshSession exchanges the refresh token, or the client credentials, for a new JWT
+func (c *Client) refreshSession(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	var resp *LoginResponse
+	var err error
+	if c.refreshToken != "" {
+		resp, err = c.RefreshSession(ctx, c.refreshToken)
+	} else {
+		resp, err = c.ClientCredentialsLogin(ctx, c.clientID, c.clientSecret)
+	}
+	if err != nil {
+		return err
+	}
+	if resp.AccessToken == "" {
+		return fmt.Errorf("refresh response did not include an access token")
+	}
 
+	c.authToken = resp.AccessToken
+	if resp.RefreshToken != "" {
+		c.refreshToken = resp.RefreshToken
+	}
+	c.tokenExpiry = time.Time{}
+	if resp.ExpiresIn > 0 {
+		c.tokenExpiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+
+	if c.onRefresh != nil {
+		c.onRefresh(resp)
+	}
 	return nil
 }
 
 // ErrorResponse represents an API error response
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
 }
 
 // ============================================
@@ -123,10 +397,12 @@ type DeviceFlowPollRequest struct {
 
 // DeviceFlowPollResponse represents the response from polling device flow
 type DeviceFlowPollResponse struct {
-	Status     string `json:"status,omitempty"`
-	AccessToken string `json:"access_token,omitempty"`
-	ExpiresIn   int    `json:"expires_in,omitempty"`
-	User        User   `json:"user,omitempty"`
+	Status           string `json:"status,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+	AccessToken      string `json:"access_token,omitempty"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	ExpiresIn        int    `json:"expires_in,omitempty"`
+	User             User   `json:"user,omitempty"`
 }
 
 // User represents a user
@@ -135,13 +411,29 @@ type User struct {
 	Email string `json:"email"`
 	Name  string `json:"name"`
 	Role  string `json:"role"`
+	Teams []Team `json:"teams,omitempty"`
+}
+
+// Team represents a team the user belongs to
+type Team struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Role        string `json:"role,omitempty"` // the user's role in the team
+	MemberCount int    `json:"member_count,omitempty"`
 }
 
 // LoginResponse represents a successful login response
 type LoginResponse struct {
-	AccessToken string `json:"access_token"`
-	ExpiresIn   int    `json:"expires_in"`
-	User        User   `json:"user"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in"`
+	User         User   `json:"user"`
+}
+
+// RefreshTokenRequest represents a request to refresh the session
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 // ============================================
@@ -149,20 +441,112 @@ type LoginResponse struct {
 // ============================================
 
 // StartDeviceFlow initiates the Keycloak device flow
-func (c *Client) StartDeviceFlow() (*DeviceFlowStartResponse, error) {
+func (c *Client) StartDeviceFlow(ctx context.Context) (*DeviceFlowStartResponse, error) {
 	var resp DeviceFlowStartResponse
-	err := c.Do("POST", "/auth/device/start", nil, &resp)
+	err := c.Do(ctx, "POST", "/auth/device/start", nil, &resp)
 	return &resp, err
 }
 
-// PollDeviceFlow polls for device flow completion
-func (c *Client) PollDeviceFlow(deviceCode string) (*DeviceFlowPollResponse, error) {
+// PollDeviceFlow polls for device flow completion.
+// OAuth error codes returned with an error status (e.g. authorization_pending,
+// access_denied) are reported in the Status field of the response alongside the error.
+func (c *Client) PollDeviceFlow(ctx context.Context, deviceCode string) (*DeviceFlowPollResponse, error) {
 	req := DeviceFlowPollRequest{DeviceCode: deviceCode}
 	var resp DeviceFlowPollResponse
-	err := c.Do("POST", "/auth/device/poll", req, &resp)
+
+	status, header, body, err := c.doRaw(ctx, "POST", "/auth/device/poll", req, false)
+	if err != nil {
+		return &resp, err
+	}
+
+	if status >= 400 {
+		apiErr := newError(status, header, body)
+		resp.Status = apiErr.Code
+		resp.ErrorDescription = apiErr.Message
+		return &resp, apiErr
+	}
+
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return &resp, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	return &resp, nil
+}
+
+// RefreshSession exchanges a refresh token for a new access token.
+// The server may rotate the refresh token, in which case the response
+// contains the replacement.
+func (c *Client) RefreshSession(ctx context.Context, refreshToken string) (*LoginResponse, error) {
+	req := RefreshTokenRequest{RefreshToken: refreshToken}
+	var resp LoginResponse
+	_, err := c.do(ctx, "POST", "/auth/refresh", req, &resp, false)
+	return &resp, err
+}
+
+// LogoutRequest represents a request to end a session
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Logout revokes the session identified by the refresh token on the server,
+// invalidating the refresh token and the access tokens issued from it
+func (c *Client) Logout(ctx context.Context, refreshToken string) error {
+	req := LogoutRequest{RefreshToken: refreshToken}
+	_, err := c.do(ctx, "POST", "/auth/logout", req, nil, false)
+	return err
+}
+
+// AuthorizationCodeRequest represents a request to exchange an authorization code
+type AuthorizationCodeRequest struct {
+	GrantType    string `json:"grant_type"`
+	Code         string `json:"code"`
+	CodeVerifier string `json:"code_verifier"`
+	RedirectURI  string `json:"redirect_uri"`
+	ClientID     string `json:"client_id"`
+}
+
+// ExchangeAuthorizationCode exchanges an authorization code and its PKCE
+// verifier for tokens
+func (c *Client) ExchangeAuthorizationCode(ctx context.Context, req *AuthorizationCodeRequest) (*LoginResponse, error) {
+	req.GrantType = "authorization_code"
+	var resp LoginResponse
+	_, err := c.do(ctx, "POST", "/auth/token", req, &resp, false)
+	return &resp, err
+}
+
+// ClientCredentialsRequest represents a service account token request
+type ClientCredentialsRequest struct {
+	GrantType    string `json:"grant_type"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// ClientCredentialsLogin obtains an access token for a service account
+func (c *Client) ClientCredentialsLogin(ctx context.Context, clientID, clientSecret string) (*LoginResponse, error) {
+	req := ClientCredentialsRequest{
+		GrantType:    "client_credentials",
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+	var resp LoginResponse
+	_, err := c.do(ctx, "POST", "/auth/token", req, &resp, false)
 	return &resp, err
 }
 
+// GetCurrentUser gets the user the client is authenticated as
+// This validates the credentials against the server
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	var resp User
+	err := c.Do(ctx, "GET", "/auth/me", nil, &resp)
+	return &resp, err
+}
+
+// AuthorizeURL returns the URL of the browser authorization endpoint
+func (c *Client) AuthorizeURL() string {
+	return c.baseURL + "/auth/authorize"
+}
+
 // ============================================
 // API Token Methods
 // ============================================
@@ -177,55 +561,108 @@ type CreateAPITokenRequest struct {
 
 // CreateAPITokenResponse represents the response from creating an API token
 type CreateAPITokenResponse struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Token       string `json:"token"`
-	TokenPrefix string `json:"token_prefix"`
-	Scope       string `json:"scope"`
-	TeamID      string `json:"team_id,omitempty"`
-	Role        string `json:"role"`
-	Teams       []string `json:"teams"`
-	ExpiresAt   time.Time `json:"expires_at"`
-	CreatedAt   time.Time `json:"created_at"`
-}
-
-// APITokenResponse represents an API token (without the actual token)
-type APITokenResponse struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
+	Token       string    `json:"token"`
 	TokenPrefix string    `json:"token_prefix"`
 	Scope       string    `json:"scope"`
 	TeamID      string    `json:"team_id,omitempty"`
 	Role        string    `json:"role"`
 	Teams       []string  `json:"teams"`
-	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
 	ExpiresAt   time.Time `json:"expires_at"`
 	CreatedAt   time.Time `json:"created_at"`
 }
 
-// APITokenListResponse represents a list of API tokens
+// APITokenResponse represents an API token (without the actual token)
+type APITokenResponse struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scope       string     `json:"scope"`
+	TeamID      string     `json:"team_id,omitempty"`
+	Role        string     `json:"role"`
+	Teams       []string   `json:"teams"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// APITokenListResponse represents a page of API tokens
 type APITokenListResponse struct {
-	Tokens []*APITokenResponse `json:"tokens"`
-	Total  int                 `json:"total"`
+	Tokens     []*APITokenResponse `json:"tokens"`
+	Total      int                 `json:"total"`
+	NextCursor string              `json:"next_cursor,omitempty"`
 }
 
 // CreateToken creates a new API token
-func (c *Client) CreateToken(req *CreateAPITokenRequest) (*CreateAPITokenResponse, error) {
+func (c *Client) CreateToken(ctx context.Context, req *CreateAPITokenRequest) (*CreateAPITokenResponse, error) {
 	var resp CreateAPITokenResponse
-	err := c.Do("POST", "/auth/tokens", req, &resp)
+	err := c.Do(ctx, "POST", "/auth/tokens", req, &resp)
 	return &resp, err
 }
 
-// ListTokens lists all API tokens for the current user
-func (c *Client) ListTokens() (*APITokenListResponse, error) {
+// ListTokens lists all API tokens for the current user, fetching every page
+func (c *Client) ListTokens(ctx context.Context) (*APITokenListResponse, error) {
+	it := c.IterTokens(ListOptions{})
+	tokens, err := it.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APITokenListResponse{Tokens: tokens, Total: len(tokens)}, nil
+}
+
+// ListTokensPage fetches one page of API tokens
+func (c *Client) ListTokensPage(ctx context.Context, req PageRequest) (*APITokenListResponse, error) {
 	var resp APITokenListResponse
-	err := c.Do("GET", "/auth/tokens", nil, &resp)
+	err := c.Do(ctx, "GET", withPage("/auth/tokens", req), nil, &resp)
+	return &resp, err
+}
+
+// IterTokens iterates over the API tokens for the current user
+func (c *Client) IterTokens(opts ListOptions) *Iterator[*APITokenResponse] {
+	return NewIterator(opts, func(ctx context.Context, req PageRequest) (*Page[*APITokenResponse], error) {
+		resp, err := c.ListTokensPage(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[*APITokenResponse]{Items: resp.Tokens, NextCursor: resp.NextCursor, Total: resp.Total}, nil
+	})
+}
+
+// GetToken gets a specific API token (without the actual token)
+func (c *Client) GetToken(ctx context.Context, tokenID string) (*APITokenResponse, error) {
+	var resp APITokenResponse
+	err := c.Do(ctx, "GET", "/auth/tokens/"+url.PathEscape(tokenID), nil, &resp)
 	return &resp, err
 }
 
 // RevokeToken revokes a specific API token
-func (c *Client) RevokeToken(tokenID string) error {
-	return c.Do("DELETE", "/auth/tokens/"+tokenID, nil, nil)
+func (c *Client) RevokeToken(ctx context.Context, tokenID string) error {
+	return c.Do(ctx, "DELETE", "/auth/tokens/"+url.PathEscape(tokenID), nil, nil)
+}
+
+// ============================================
+// Team Methods
+// ============================================
+
+// TeamListResponse represents a list of teams
+type TeamListResponse struct {
+	Teams []*Team `json:"teams"`
+	Total int     `json:"total"`
+}
+
+// ListTeams lists the teams the current user belongs to
+func (c *Client) ListTeams(ctx context.Context) (*TeamListResponse, error) {
+	var resp TeamListResponse
+	err := c.Do(ctx, "GET", "/teams", nil, &resp)
+	return &resp, err
+}
+
+// GetTeam gets a specific team
+func (c *Client) GetTeam(ctx context.Context, teamID string) (*Team, error) {
+	var resp Team
+	err := c.Do(ctx, "GET", "/teams/"+url.PathEscape(teamID), nil, &resp)
+	return &resp, err
 }
 
 // ============================================
@@ -234,15 +671,22 @@ func (c *Client) RevokeToken(tokenID string) error {
 
 // MCPInfoResponse represents MCP server information
 type MCPInfoResponse struct {
-	Name        string `json:"name"`
-	Version     string `json:"version"`
-	Instructions string `json:"instructions"`
+	Name                  string `json:"name"`
+	Version               string `json:"version"`
+	Instructions          string `json:"instructions"`
+	MinCLIVersion         string `json:"min_cli_version,omitempty"`
+	RecommendedCLIVersion string `json:"recommended_cli_version,omitempty"`
+}
+
+// Compatibility returns the CLI versions the platform supports
+func (r *MCPInfoResponse) Compatibility() Compatibility {
+	return Compatibility{MinVersion: r.MinCLIVersion, RecommendedVersion: r.RecommendedCLIVersion}
 }
 
 // GetMCPInfo gets MCP server information
-func (c *Client) GetMCPInfo() (*MCPInfoResponse, error) {
+func (c *Client) GetMCPInfo(ctx context.Context) (*MCPInfoResponse, error) {
 	var resp MCPInfoResponse
-	err := c.Do("GET", "/mcp/info", nil, &resp)
+	err := c.Do(ctx, "GET", "/mcp/info", nil, &resp)
 	return &resp, err
 }
 
@@ -263,21 +707,25 @@ type SkillsListResponse struct {
 }
 
 // ListSkills lists all available skills
-func (c *Client) ListSkills() (*SkillsListResponse, error) {
+func (c *Client) ListSkills(ctx context.Context) (*SkillsListResponse, error) {
 	var resp SkillsListResponse
-	err := c.Do("GET", "/skills", nil, &resp)
+	err := c.Do(ctx, "GET", "/skills", nil, &resp)
 	return &resp, err
 }
 
 // DownloadSkill downloads a skill by name
-func (c *Client) DownloadSkill(name string) (string, error) {
+func (c *Client) DownloadSkill(ctx context.Context, name string) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
 	url := c.baseURL + "/skills/" + name
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Accept", "text/markdown")
+	req.Header.Set("User-Agent", c.userAgent)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -113,7 +115,7 @@ func TestClient_Do(t *testing.T) {
 
 			// Make request
 			var result map[string]string
-			err := client.Do(tt.method, tt.path, tt.body, &result)
+			err := client.Do(context.Background(), tt.method, tt.path, tt.body, &result)
 
 			// Check error
 			if tt.expectError && err == nil {
@@ -148,7 +150,7 @@ func TestClient_StartDeviceFlow(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL)
-	resp, err := client.StartDeviceFlow()
+	resp, err := client.StartDeviceFlow(context.Background())
 
 	if err != nil {
 		t.Fatalf("StartDeviceFlow() failed: %v", err)
@@ -209,7 +211,8 @@ func TestClient_PollDeviceFlow(t *testing.T) {
 			responseBody: map[string]string{
 				"error": "expired_token",
 			},
-			expectError: true,
+			expectError:  true,
+			expectStatus: "expired_token",
 		},
 	}
 
@@ -227,7 +230,7 @@ func TestClient_PollDeviceFlow(t *testing.T) {
 			defer server.Close()
 
 			client := NewClient(server.URL)
-			resp, err := client.PollDeviceFlow(tt.deviceCode)
+			resp, err := client.PollDeviceFlow(context.Background(), tt.deviceCode)
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error, got nil")
@@ -276,7 +279,7 @@ func TestClient_CreateToken(t *testing.T) {
 		ExpiresIn: 365 * 24 * 60 * 60,
 	}
 
-	resp, err := client.CreateToken(req)
+	resp, err := client.CreateToken(context.Background(), req)
 
 	if err != nil {
 		t.Fatalf("CreateToken() failed: %v", err)
@@ -332,7 +335,7 @@ func TestClient_ListTokens(t *testing.T) {
 	client := NewClient(server.URL)
 	client.SetAuthToken("test-auth-token")
 
-	resp, err := client.ListTokens()
+	resp, err := client.ListTokens(context.Background())
 
 	if err != nil {
 		t.Fatalf("ListTokens() failed: %v", err)
@@ -347,6 +350,116 @@ func TestClient_ListTokens(t *testing.T) {
 	}
 }
 
+func TestClient_Logout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/auth/logout" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Authorization = %v, want none", auth)
+		}
+
+		var req LogoutRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.RefreshToken != "refresh-123" {
+			t.Errorf("RefreshToken = %v, want refresh-123", req.RefreshToken)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetAuthToken("test-auth-token")
+
+	if err := client.Logout(context.Background(), "refresh-123"); err != nil {
+		t.Fatalf("Logout() failed: %v", err)
+	}
+}
+
+func TestClient_ListTeamsWithTeamHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/teams" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		if team := r.Header.Get(TeamHeader); team != "team-1" {
+			t.Errorf("%s = %v, want team-1", TeamHeader, team)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&TeamListResponse{
+			Teams: []*Team{
+				{ID: "team-1", Name: "Platform", Role: "owner"},
+				{ID: "team-2", Name: "Payments", Role: "member"},
+			},
+			Total: 2,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetAuthToken("test-auth-token")
+	client.SetTeam("team-1")
+
+	resp, err := client.ListTeams(context.Background())
+	if err != nil {
+		t.Fatalf("ListTeams() failed: %v", err)
+	}
+
+	if len(resp.Teams) != 2 {
+		t.Fatalf("len(Teams) = %d, want 2", len(resp.Teams))
+	}
+
+	if resp.Teams[1].Name != "Payments" {
+		t.Errorf("Teams[1].Name = %v, want Payments", resp.Teams[1].Name)
+	}
+}
+
+func TestClient_GetToken(t *testing.T) {
+	lastUsed := time.Now().Add(-1 * time.Hour)
+	expectedResp := &APITokenResponse{
+		ID:          "token-123",
+		Name:        "CI Token",
+		TokenPrefix: "dp_abc...",
+		Scope:       "team",
+		TeamID:      "team-1",
+		LastUsedAt:  &lastUsed,
+		ExpiresAt:   time.Now().Add(30 * 24 * time.Hour),
+		CreatedAt:   time.Now(),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/auth/tokens/token-123" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(expectedResp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetAuthToken("test-auth-token")
+
+	resp, err := client.GetToken(context.Background(), "token-123")
+
+	if err != nil {
+		t.Fatalf("GetToken() failed: %v", err)
+	}
+
+	if resp.Name != expectedResp.Name {
+		t.Errorf("Name = %v, want %v", resp.Name, expectedResp.Name)
+	}
+
+	if resp.LastUsedAt == nil {
+		t.Error("Expected LastUsedAt to be set")
+	}
+}
+
 func TestClient_RevokeToken(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "DELETE" || r.URL.Path != "/auth/tokens/token-123" {
@@ -362,7 +475,7 @@ func TestClient_RevokeToken(t *testing.T) {
 	client := NewClient(server.URL)
 	client.SetAuthToken("test-auth-token")
 
-	err := client.RevokeToken("token-123")
+	err := client.RevokeToken(context.Background(), "token-123")
 
 	if err != nil {
 		t.Errorf("RevokeToken() failed: %v", err)
@@ -390,7 +503,7 @@ func TestClient_GetMCPInfo(t *testing.T) {
 	client := NewClient(server.URL)
 	client.SetAPIToken("test-api-token")
 
-	resp, err := client.GetMCPInfo()
+	resp, err := client.GetMCPInfo(context.Background())
 
 	if err != nil {
 		t.Fatalf("GetMCPInfo() failed: %v", err)
@@ -434,7 +547,7 @@ func TestClient_ListSkills(t *testing.T) {
 
 	client := NewClient(server.URL)
 
-	resp, err := client.ListSkills()
+	resp, err := client.ListSkills(context.Background())
 
 	if err != nil {
 		t.Fatalf("ListSkills() failed: %v", err)
@@ -461,7 +574,7 @@ func TestClient_DownloadSkill(t *testing.T) {
 
 	client := NewClient(server.URL)
 
-	content, err := client.DownloadSkill("test.md")
+	content, err := client.DownloadSkill(context.Background(), "test.md")
 
 	if err != nil {
 		t.Fatalf("DownloadSkill() failed: %v", err)
@@ -471,3 +584,169 @@ func TestClient_DownloadSkill(t *testing.T) {
 		t.Errorf("Content = %v, want %v", content, expectedContent)
 	}
 }
+
+func TestClient_RefreshesExpiringToken(t *testing.T) {
+	var refreshed bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/refresh":
+			var req RefreshTokenRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.RefreshToken != "old-refresh-token" {
+				t.Errorf("RefreshToken = %v, want old-refresh-token", req.RefreshToken)
+			}
+			if r.Header.Get("Authorization") != "" {
+				t.Error("Expected refresh request to be sent without Authorization header")
+			}
+			json.NewEncoder(w).Encode(LoginResponse{
+				AccessToken:  "new-access-token",
+				RefreshToken: "new-refresh-token",
+				ExpiresIn:    3600,
+			})
+		case "/test":
+			if got := r.Header.Get("Authorization"); got != "Bearer new-access-token" {
+				t.Errorf("Authorization = %v, want Bearer new-access-token", got)
+			}
+			json.NewEncoder(w).Encode(map[string]string{"message": "success"})
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetAuthToken("old-access-token")
+	client.SetRefreshToken("old-refresh-token")
+	client.SetTokenExpiry(time.Now().Add(10 * time.Second))
+	client.OnTokenRefresh(func(resp *LoginResponse) {
+		refreshed = true
+		if resp.RefreshToken != "new-refresh-token" {
+			t.Errorf("RefreshToken = %v, want new-refresh-token", resp.RefreshToken)
+		}
+	})
+
+	if err := client.Do(context.Background(), "GET", "/test", nil, nil); err != nil {
+		t.Fatalf("Do() failed: %v", err)
+	}
+
+	if !refreshed {
+		t.Error("Expected OnTokenRefresh callback to be called")
+	}
+}
+
+func TestClient_RefreshesOnUnauthorized(t *testing.T) {
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/refresh":
+			json.NewEncoder(w).Encode(LoginResponse{
+				AccessToken: "new-access-token",
+				ExpiresIn:   3600,
+			})
+		case "/test":
+			calls++
+			if r.Header.Get("Authorization") != "Bearer new-access-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]string{"error": "token revoked"})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"message": "success"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetAuthToken("revoked-access-token")
+	client.SetRefreshToken("refresh-token")
+	client.SetTokenExpiry(time.Now().Add(time.Hour))
+
+	if err := client.Do(context.Background(), "GET", "/test", nil, nil); err != nil {
+		t.Fatalf("Do() failed: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected request to be retried once, got %d calls", calls)
+	}
+}
+
+func TestClient_ClientCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/token":
+			var req ClientCredentialsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.GrantType != "client_credentials" || req.ClientID != "sa-ci" || req.ClientSecret != "sa-secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+				return
+			}
+			json.NewEncoder(w).Encode(LoginResponse{
+				AccessToken: "sa-access-token",
+				ExpiresIn:   3600,
+			})
+		case "/auth/me":
+			if r.Header.Get("Authorization") != "Bearer sa-access-token" {
+				t.Errorf("Authorization = %v, want Bearer sa-access-token", r.Header.Get("Authorization"))
+			}
+			json.NewEncoder(w).Encode(User{ID: "sa-ci", Role: "service"})
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	// No access token yet: one is obtained with the client credentials
+	client := NewClient(server.URL)
+	client.SetClientCredentials("sa-ci", "sa-secret")
+
+	user, err := client.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentUser() failed: %v", err)
+	}
+
+	if user.ID != "sa-ci" {
+		t.Errorf("ID = %v, want sa-ci", user.ID)
+	}
+}
+
+func TestClient_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetAuthToken("test-auth-token")
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+	t.Run("per-request timeout", func(t *testing.T) {
+		client.SetTimeout(50 * time.Millisecond)
+		defer client.SetTimeout(DefaultTimeout)
+
+		err := client.Do(context.Background(), "GET", "/slow", nil, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Do() error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		err := client.Do(ctx, "GET", "/slow", nil, nil)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Do() error = %v, want context.Canceled", err)
+		}
+	})
+}
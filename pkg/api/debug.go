@@ -0,0 +1,144 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultDebugBodyLimit is how much of each body the debug transport prints
+	defaultDebugBodyLimit = 2048
+	// maxCapturedBody is how much of a response body is kept for redaction;
+	// JSON must be complete to be redacted field by field
+	maxCapturedBody = 1 << 20
+)
+
+// DebugTransport logs HTTP traffic with secrets redacted
+//
+// For each request it writes the method, URL, status, latency, request ID
+// and the truncated request and response bodies.
+type DebugTransport struct {
+	Base      http.RoundTripper // defaults to http.DefaultTransport
+	Out       io.Writer
+	BodyLimit int // bytes of each body to print (defaults to 2048)
+
+	mu sync.Mutex
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *DebugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limit := t.BodyLimit
+	if limit <= 0 {
+		limit = defaultDebugBodyLimit
+	}
+
+	reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	t.printf("--> %s %s", req.Method, RedactURL(req.URL))
+	if len(reqBody) > 0 {
+		t.printf("    %s", truncate(string(RedactBody(reqBody)), limit))
+	}
+
+	start := time.Now()
+	resp, err := base(t.Base).RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.printf("<-- %s %s failed after %s: %v", req.Method, RedactURL(req.URL), latency, err)
+		return nil, err
+	}
+
+	requestID := resp.Header.Get(RequestIDHeader)
+	if requestID == "" {
+		requestID = "-"
+	}
+	t.printf("<-- %s %s (%s, request ID %s)", resp.Status, RedactURL(req.URL), latency, requestID)
+
+	// The body is printed once the caller has read it, so streams are not buffered
+	resp.Body = &captureBody{
+		ReadCloser: resp.Body,
+		limit:      max(limit, maxCapturedBody),
+		onClose: func(body []byte, truncated bool) {
+			if len(body) == 0 {
+				return
+			}
+			text := string(RedactBody(body))
+			if truncated {
+				text += "..."
+			}
+			t.printf("    %s", truncate(text, limit))
+		},
+	}
+	return resp, nil
+}
+
+// printf writes one debug line
+func (t *DebugTransport) printf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.Out, "[debug] "+format+"\n", args...)
+}
+
+// base returns rt, or the default transport if rt is nil
+func base(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		return http.DefaultTransport
+	}
+	return rt
+}
+
+// peekRequestBody returns a copy of the request body without consuming it
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// captureBody records up to limit bytes of a response body as it is read
+// and passes them to onClose
+type captureBody struct {
+	io.ReadCloser
+	limit   int
+	buf     bytes.Buffer
+	total   int
+	onClose func(body []byte, truncated bool)
+	once    sync.Once
+}
+
+func (b *captureBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := b.limit - b.buf.Len(); room > 0 && n > 0 {
+		b.buf.Write(p[:min(n, room)])
+	}
+	b.total += n
+	return n, err
+}
+
+func (b *captureBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.onClose(b.buf.Bytes(), b.total > b.buf.Len())
+	})
+	return err
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Deployment is the running application of a pipeline
+type Deployment struct {
+	PipelineID    string     `json:"pipeline_id"`
+	AppName       string     `json:"app_name"`
+	Status        string     `json:"status"`
+	Replicas      int        `json:"replicas"`
+	ReadyReplicas int        `json:"ready_replicas"`
+	Image         string     `json:"image,omitempty"`
+	RunID         string     `json:"run_id,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	RestartedAt   *time.Time `json:"restarted_at,omitempty"`
+}
+
+// PodLog is the log of one pod of a deployment
+type PodLog struct {
+	Pod string `json:"pod"`
+	Log string `json:"log"`
+}
+
+// DeploymentLogs represents the logs of the pods of a deployment
+type DeploymentLogs struct {
+	PipelineID string   `json:"pipeline_id"`
+	Pods       []PodLog `json:"pods"`
+}
+
+// MaskedValue replaces secret values in DeploymentEnv
+const MaskedValue = "********"
+
+// DeploymentEnv represents the environment of a pod of a deployment.
+// Secret values are replaced with MaskedValue.
+type DeploymentEnv struct {
+	PipelineID string            `json:"pipeline_id"`
+	Pod        string            `json:"pod"`
+	Env        map[string]string `json:"env"`
+}
+
+// deploymentPath returns the path of a pipeline's deployment
+func deploymentPath(pipelineID string) string {
+	return "/pipelines/" + url.PathEscape(pipelineID) + "/deployment"
+}
+
+// GetDeployment gets the deployment of a pipeline. It fails with a 404 error
+// until a run of the pipeline has succeeded.
+func (c *Client) GetDeployment(ctx context.Context, pipelineID string) (*Deployment, error) {
+	var resp Deployment
+	err := c.Do(ctx, "GET", deploymentPath(pipelineID), nil, &resp)
+	return &resp, err
+}
+
+// RestartDeployment performs a rolling restart of a pipeline's deployment
+func (c *Client) RestartDeployment(ctx context.Context, pipelineID string) (*Deployment, error) {
+	var resp Deployment
+	err := c.Do(ctx, "POST", deploymentPath(pipelineID)+"/restart", nil, &resp)
+	return &resp, err
+}
+
+// GetDeploymentLogs gets the logs of all pods of a pipeline's deployment
+func (c *Client) GetDeploymentLogs(ctx context.Context, pipelineID string) (*DeploymentLogs, error) {
+	var resp DeploymentLogs
+	err := c.Do(ctx, "GET", deploymentPath(pipelineID)+"/logs", nil, &resp)
+	return &resp, err
+}
+
+// GetDeploymentEnv gets the environment variables of a pipeline's
+// deployment, with secrets masked
+func (c *Client) GetDeploymentEnv(ctx context.Context, pipelineID string) (*DeploymentEnv, error) {
+	var resp DeploymentEnv
+	err := c.Do(ctx, "GET", deploymentPath(pipelineID)+"/env", nil, &resp)
+	return &resp, err
+}
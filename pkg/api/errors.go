@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// RequestIDHeader is the response header carrying the server request ID
+const RequestIDHeader = "X-Request-ID"
+
+// maxErrorBody limits how much of a non-JSON error body is used as the message
+const maxErrorBody = 512
+
+// Error is an error response from the API
+//
+// Both the OAuth style body ({"error": ..., "error_description": ...}) and
+// RFC 7807 problem details (application/problem+json) are decoded.
+type Error struct {
+	StatusCode int    // HTTP status code
+	Code       string // machine-readable error code, e.g. "not_found" or "authorization_pending"
+	Message    string // human-readable description
+	RequestID  string // server request ID, for support tickets
+	Body       []byte // raw response body
+
+	// RFC 7807 problem details, when the server sends them
+	Type     string
+	Title    string
+	Instance string
+}
+
+// Error returns the error message
+func (e *Error) Error() string {
+	detail := e.Message
+	switch {
+	case detail == "":
+		detail = e.Code
+	case e.Code != "" && e.Code != detail:
+		detail = e.Code + ": " + detail
+	}
+	if detail == "" {
+		detail = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, detail)
+}
+
+// errorBody is the union of the error formats sent by the platform
+type errorBody struct {
+	// OAuth style
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+	// Generic
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+	// RFC 7807
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+}
+
+// newError builds an *Error from an error response
+func newError(status int, header http.Header, body []byte) *Error {
+	e := &Error{
+		StatusCode: status,
+		Body:       body,
+		RequestID:  header.Get(RequestIDHeader),
+	}
+
+	var b errorBody
+	if err := json.Unmarshal(body, &b); err != nil {
+		// Not JSON, e.g. an HTML page from a gateway
+		if mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type")); mediaType != "text/html" {
+			e.Message = truncate(strings.TrimSpace(string(body)), maxErrorBody)
+		}
+		return e
+	}
+
+	e.Code = firstNonEmpty(b.Code, b.Error)
+	e.Message = firstNonEmpty(b.Detail, b.ErrorDescription, b.Message, b.Title)
+	e.RequestID = firstNonEmpty(e.RequestID, b.RequestID)
+	e.Type = b.Type
+	e.Title = b.Title
+	e.Instance = b.Instance
+	return e
+}
+
+// IsUnauthorized checks if err is an API error with status 401
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden checks if err is an API error with status 403
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound checks if err is an API error with status 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict checks if err is an API error with status 409
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited checks if err is an API error with status 429
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsServerError checks if err is an API error with a 5xx status
+func IsServerError(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
+
+// hasStatus checks if err is an API error with the status code
+func hasStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
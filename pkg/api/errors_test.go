@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		requestID   string
+		body        string
+		wantCode    string
+		wantMessage string
+		wantReqID   string
+		wantString  string
+	}{
+		{
+			name:        "oauth style",
+			status:      http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"error": "invalid_grant", "error_description": "Refresh token expired"}`,
+			wantCode:    "invalid_grant",
+			wantMessage: "Refresh token expired",
+			wantString:  "API error (status 400): invalid_grant: Refresh token expired",
+		},
+		{
+			name:        "problem details",
+			status:      http.StatusConflict,
+			contentType: "application/problem+json",
+			body:        `{"type": "https://platform.example.com/problems/conflict", "title": "Conflict", "detail": "Project name already taken", "code": "project_exists", "request_id": "req-body"}`,
+			wantCode:    "project_exists",
+			wantMessage: "Project name already taken",
+			wantReqID:   "req-body",
+		},
+		{
+			name:        "request ID header wins",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			requestID:   "req-header",
+			body:        `{"message": "pipeline not found", "request_id": "req-body"}`,
+			wantMessage: "pipeline not found",
+			wantReqID:   "req-header",
+			wantString:  "API error (status 404): pipeline not found",
+		},
+		{
+			name:        "gateway html page",
+			status:      http.StatusBadGateway,
+			contentType: "text/html; charset=utf-8",
+			body:        `<html><body>Bad Gateway</body></html>`,
+			wantString:  "API error (status 502): Bad Gateway",
+		},
+		{
+			name:        "plain text",
+			status:      http.StatusInternalServerError,
+			contentType: "text/plain",
+			body:        "database unavailable\n",
+			wantMessage: "database unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("Content-Type", tt.contentType)
+			if tt.requestID != "" {
+				header.Set(RequestIDHeader, tt.requestID)
+			}
+
+			err := newError(tt.status, header, []byte(tt.body))
+
+			if err.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", err.StatusCode, tt.status)
+			}
+			if err.Code != tt.wantCode {
+				t.Errorf("Code = %q, want %q", err.Code, tt.wantCode)
+			}
+			if err.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", err.Message, tt.wantMessage)
+			}
+			if err.RequestID != tt.wantReqID {
+				t.Errorf("RequestID = %q, want %q", err.RequestID, tt.wantReqID)
+			}
+			if tt.wantString != "" && err.Error() != tt.wantString {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantString)
+			}
+			if string(err.Body) != tt.body {
+				t.Errorf("Body = %q, want raw body", err.Body)
+			}
+		})
+	}
+}
+
+func TestClient_ReturnsTypedErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/missing":
+			w.Header().Set(RequestIDHeader, "req-123")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not_found"}`))
+		case "/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+	err := client.Do(context.Background(), "GET", "/unauthorized", nil, nil)
+	if !IsUnauthorized(err) || IsNotFound(err) {
+		t.Errorf("IsUnauthorized(%v) = false", err)
+	}
+
+	err = client.Do(context.Background(), "GET", "/missing", nil, nil)
+	wrapped := fmt.Errorf("failed to get pipeline: %w", err)
+	if !IsNotFound(wrapped) {
+		t.Errorf("IsNotFound(%v) = false", wrapped)
+	}
+
+	var apiErr *Error
+	if !errors.As(wrapped, &apiErr) || apiErr.RequestID != "req-123" || apiErr.Code != "not_found" {
+		t.Errorf("errors.As() = %+v, want request ID and code", apiErr)
+	}
+
+	err = client.Do(context.Background(), "GET", "/limited", nil, nil)
+	if !IsRateLimited(err) {
+		t.Errorf("IsRateLimited(%v) = false", err)
+	}
+}
@@ -0,0 +1,237 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// HARRecorder captures HTTP traffic in HAR 1.2 format, with secrets redacted,
+// so it can be shared with the platform team
+type HARRecorder struct {
+	// Creator is recorded as the creator application (e.g. "chrono 1.2.0")
+	CreatorName    string
+	CreatorVersion string
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// NewHARRecorder creates a recorder
+func NewHARRecorder(creatorName, creatorVersion string) *HARRecorder {
+	return &HARRecorder{CreatorName: creatorName, CreatorVersion: creatorVersion}
+}
+
+// Transport returns a round tripper that records requests made through base
+func (r *HARRecorder) Transport(base http.RoundTripper) http.RoundTripper {
+	return &harTransport{recorder: r, base: base}
+}
+
+// WriteFile writes the recorded traffic to a HAR file
+func (r *HARRecorder) WriteFile(path string) error {
+	r.mu.Lock()
+	entries := append([]harEntry{}, r.entries...)
+	r.mu.Unlock()
+
+	har := harFile{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: r.CreatorName, Version: r.CreatorVersion},
+		Entries: entries,
+	}}
+	data, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal trace: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write trace file: %w", err)
+	}
+	return nil
+}
+
+// add records a completed entry
+func (r *HARRecorder) add(entry harEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+}
+
+// harTransport records each request and response in the recorder
+type harTransport struct {
+	recorder *HARRecorder
+	base     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *harTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := harEntry{
+		StartedDateTime: time.Now().Format(time.RFC3339Nano),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         RedactURL(req.URL),
+			HTTPVersion: req.Proto,
+			Headers:     harHeaders(RedactHeaders(req.Header)),
+			QueryString: []harNameValue{},
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Cache: struct{}{},
+	}
+	if u, err := url.Parse(entry.Request.URL); err == nil {
+		for name, values := range u.Query() {
+			for _, v := range values {
+				entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: v})
+			}
+		}
+	}
+	if len(reqBody) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     string(RedactBody(reqBody)),
+		}
+	}
+
+	start := time.Now()
+	resp, err := base(t.base).RoundTrip(req)
+	if err != nil {
+		entry.Time = msSince(start)
+		entry.Timings = harTimings{Wait: entry.Time}
+		entry.Response = harResponse{
+			StatusText:  err.Error(),
+			Headers:     []harNameValue{},
+			Cookies:     []harNameValue{},
+			Content:     harContent{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		}
+		t.recorder.add(entry)
+		return nil, err
+	}
+	wait := msSince(start)
+
+	resp.Body = &captureBody{
+		ReadCloser: resp.Body,
+		limit:      maxCapturedBody,
+		onClose: func(body []byte, truncated bool) {
+			entry.Time = msSince(start)
+			entry.Timings = harTimings{Wait: wait, Receive: entry.Time - wait}
+			entry.Response = harResponse{
+				Status:      resp.StatusCode,
+				StatusText:  http.StatusText(resp.StatusCode),
+				HTTPVersion: resp.Proto,
+				Headers:     harHeaders(RedactHeaders(resp.Header)),
+				Cookies:     []harNameValue{},
+				Content: harContent{
+					Size:     len(body),
+					MimeType: resp.Header.Get("Content-Type"),
+					Text:     string(RedactBody(body)),
+				},
+				HeadersSize: -1,
+				BodySize:    len(body),
+			}
+			if truncated {
+				entry.Comment = "response body truncated"
+			}
+			t.recorder.add(entry)
+		},
+	}
+	return resp, nil
+}
+
+// msSince returns the milliseconds elapsed since start
+func msSince(start time.Time) float64 {
+	return float64(time.Since(start).Microseconds()) / 1000
+}
+
+// harHeaders converts headers to HAR name/value pairs
+func harHeaders(header http.Header) []harNameValue {
+	out := []harNameValue{}
+	for name, values := range header {
+		for _, v := range values {
+			out = append(out, harNameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+// HAR 1.2 types (http://www.softwareishard.com/blog/har-12-spec/)
+
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
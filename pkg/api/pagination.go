@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageSize is the number of items an Iterator requests per page
+const DefaultPageSize = 100
+
+// PageRequest selects one page of a list endpoint. Endpoints that return a
+// next cursor are paged with Cursor; others with Offset.
+type PageRequest struct {
+	// Limit is the maximum number of items to return; 0 uses the server default
+	Limit int
+	// Cursor is the next cursor returned with the previous page
+	Cursor string
+	// Offset is the number of items to skip when paging without a cursor
+	Offset int
+}
+
+// withPage adds the paging query parameters to an API path
+func withPage(path string, req PageRequest) string {
+	query := url.Values{}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+	if req.Cursor != "" {
+		query.Set("cursor", req.Cursor)
+	} else if req.Offset > 0 {
+		query.Set("offset", strconv.Itoa(req.Offset))
+	}
+	if len(query) == 0 {
+		return path
+	}
+	if strings.Contains(path, "?") {
+		return path + "&" + query.Encode()
+	}
+	return path + "?" + query.Encode()
+}
+
+// Page is one page of a list endpoint
+type Page[T any] struct {
+	Items []T
+	// NextCursor is the cursor of the next page; empty on the last page or
+	// for endpoints paged by offset
+	NextCursor string
+	// Total is the number of items across all pages, or 0 if unknown
+	Total int
+}
+
+// ListOptions controls how an Iterator pages through a list
+type ListOptions struct {
+	// PageSize is the number of items requested per page; 0 uses DefaultPageSize
+	PageSize int
+	// Limit is the maximum number of items to return; 0 returns all items
+	Limit int
+}
+
+// Iterator returns the items of a list endpoint one at a time, fetching pages
+// lazily so only one page is held in memory:
+//
+//	it := client.IterTokens(api.ListOptions{Limit: 50})
+//	for it.Next(ctx) {
+//		fmt.Println(it.Item().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	fetch func(context.Context, PageRequest) (*Page[T], error)
+	opts  ListOptions
+
+	items     []T
+	pos       int
+	item      T
+	count     int
+	offset    int
+	cursor    string
+	cursorSet bool
+	total     int
+	done      bool
+	err       error
+}
+
+// NewIterator returns an iterator over the pages returned by fetch
+func NewIterator[T any](opts ListOptions, fetch func(ctx context.Context, req PageRequest) (*Page[T], error)) *Iterator[T] {
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	return &Iterator[T]{fetch: fetch, opts: opts}
+}
+
+// Next advances to the next item, fetching the next page when needed. It
+// returns false when the list or the limit is exhausted, or on error.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil || (it.opts.Limit > 0 && it.count >= it.opts.Limit) {
+		return false
+	}
+	for it.pos >= len(it.items) {
+		if it.done || !it.fetchPage(ctx) {
+			return false
+		}
+	}
+	it.item = it.items[it.pos]
+	it.pos++
+	it.count++
+	return true
+}
+
+// fetchPage replaces the current page with the next one
+func (it *Iterator[T]) fetchPage(ctx context.Context) bool {
+	size := it.opts.PageSize
+	if it.opts.Limit > 0 {
+		size = min(size, it.opts.Limit-it.count)
+	}
+	req := PageRequest{Limit: size, Cursor: it.cursor}
+	if it.cursor == "" {
+		req.Offset = it.offset
+	}
+
+	page, err := it.fetch(ctx, req)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if len(page.Items) > 0 && reflect.DeepEqual(page.Items, it.items) {
+		// The same page again: the endpoint ignores the offset or cursor and
+		// would return it forever
+		it.items, it.pos, it.done = nil, 0, true
+		return true
+	}
+	it.items, it.pos = page.Items, 0
+	it.offset += len(page.Items)
+	it.total = page.Total
+	it.cursor = page.NextCursor
+
+	switch {
+	case page.NextCursor != "" && page.NextCursor == req.Cursor:
+		// An unchanged cursor would fetch the same page again
+		it.done = true
+	case page.NextCursor != "":
+		it.cursorSet = true
+	case it.cursorSet, len(page.Items) == 0:
+		it.done = true
+	case page.Total > 0:
+		// Servers may cap the page size, so a short page is only the last
+		// one when the total says so
+		it.done = it.offset >= page.Total
+	case len(page.Items) != size:
+		// Without a total, a short page is the last one; a longer page means
+		// the endpoint ignores paging and returned everything
+		it.done = true
+	}
+	return true
+}
+
+// Item returns the current item
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Total returns the number of items across all pages as reported by the
+// server with the last page fetched, or 0 if unknown
+func (it *Iterator[T]) Total() int {
+	return it.total
+}
+
+// All returns the remaining items, up to the limit
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
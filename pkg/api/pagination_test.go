@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// pagedList serves items the way a list endpoint does
+type pagedList struct {
+	items    []int
+	cursors  bool // return next cursors instead of relying on offsets
+	unpaged  bool // ignore paging and return all items
+	maxLimit int  // cap the page size like servers do, if set
+	requests []PageRequest
+}
+
+func (l *pagedList) fetch(ctx context.Context, req PageRequest) (*Page[int], error) {
+	l.requests = append(l.requests, req)
+	if l.unpaged {
+		return &Page[int]{Items: l.items, Total: len(l.items)}, nil
+	}
+
+	offset := req.Offset
+	if req.Cursor != "" {
+		offset, _ = strconv.Atoi(req.Cursor)
+	}
+	limit := req.Limit
+	if l.maxLimit > 0 {
+		limit = min(limit, l.maxLimit)
+	}
+	end := min(offset+limit, len(l.items))
+	page := &Page[int]{Items: l.items[min(offset, end):end], Total: len(l.items)}
+	if l.cursors && end < len(l.items) {
+		page.NextCursor = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+func numbers(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func TestIterator(t *testing.T) {
+	tests := []struct {
+		name      string
+		list      *pagedList
+		opts      ListOptions
+		wantItems int
+		wantPages int
+	}{
+		{name: "offset paging", list: &pagedList{items: numbers(25)}, opts: ListOptions{PageSize: 10}, wantItems: 25, wantPages: 3},
+		{name: "offset paging, last page full", list: &pagedList{items: numbers(20)}, opts: ListOptions{PageSize: 10}, wantItems: 20, wantPages: 2},
+		{name: "cursor paging", list: &pagedList{items: numbers(25), cursors: true}, opts: ListOptions{PageSize: 10}, wantItems: 25, wantPages: 3},
+		{name: "limit", list: &pagedList{items: numbers(25), cursors: true}, opts: ListOptions{PageSize: 10, Limit: 15}, wantItems: 15, wantPages: 2},
+		{name: "limit below page size", list: &pagedList{items: numbers(25)}, opts: ListOptions{Limit: 5}, wantItems: 5, wantPages: 1},
+		{name: "empty list", list: &pagedList{}, opts: ListOptions{}, wantItems: 0, wantPages: 1},
+		{name: "server caps page size", list: &pagedList{items: numbers(25), maxLimit: 4}, opts: ListOptions{PageSize: 10}, wantItems: 25, wantPages: 7},
+		{name: "endpoint without paging", list: &pagedList{items: numbers(25), unpaged: true}, opts: ListOptions{PageSize: 10}, wantItems: 25, wantPages: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := NewIterator(tt.opts, tt.list.fetch)
+			items, err := it.All(context.Background())
+			if err != nil {
+				t.Fatalf("All() failed: %v", err)
+			}
+			if len(items) != tt.wantItems || (len(items) > 0 && !reflect.DeepEqual(items, numbers(tt.wantItems))) {
+				t.Errorf("got %d items %v, want %d", len(items), items, tt.wantItems)
+			}
+			if len(tt.list.requests) != tt.wantPages {
+				t.Errorf("fetched %d pages %+v, want %d", len(tt.list.requests), tt.list.requests, tt.wantPages)
+			}
+			if tt.wantItems > 0 && it.Total() != len(tt.list.items) {
+				t.Errorf("Total() = %d, want %d", it.Total(), len(tt.list.items))
+			}
+		})
+	}
+}
+
+func TestIterator_StopsOnRepeatedPage(t *testing.T) {
+	tests := []struct {
+		name  string
+		fetch func(req PageRequest) *Page[int]
+	}{
+		{
+			// Full pages without a total or cursor, whatever the offset
+			name:  "offset ignored",
+			fetch: func(req PageRequest) *Page[int] { return &Page[int]{Items: numbers(req.Limit)} },
+		},
+		{
+			name:  "cursor repeated",
+			fetch: func(req PageRequest) *Page[int] { return &Page[int]{Items: numbers(req.Limit), NextCursor: "same"} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			it := NewIterator(ListOptions{PageSize: 10}, func(ctx context.Context, req PageRequest) (*Page[int], error) {
+				if requests++; requests > 5 {
+					return nil, errors.New("still paging after 5 requests")
+				}
+				return tt.fetch(req), nil
+			})
+			items, err := it.All(context.Background())
+			if err != nil {
+				t.Fatalf("All() failed: %v", err)
+			}
+			if !reflect.DeepEqual(items, numbers(10)) {
+				t.Errorf("All() = %v, want the first page once", items)
+			}
+		})
+	}
+}
+
+func TestIterator_FetchesLazily(t *testing.T) {
+	list := &pagedList{items: numbers(25), cursors: true}
+	it := NewIterator(ListOptions{PageSize: 10}, list.fetch)
+
+	for range 10 {
+		it.Next(context.Background())
+	}
+	if len(list.requests) != 1 {
+		t.Errorf("fetched %d pages after 10 items, want 1", len(list.requests))
+	}
+	it.Next(context.Background())
+	if got := list.requests[1]; got.Cursor != "10" || got.Limit != 10 {
+		t.Errorf("second page request = %+v", got)
+	}
+}
+
+func TestIterator_StopsOnError(t *testing.T) {
+	errFetch := errors.New("server failed")
+	pages := 0
+	it := NewIterator(ListOptions{PageSize: 2}, func(ctx context.Context, req PageRequest) (*Page[int], error) {
+		pages++
+		if pages == 2 {
+			return nil, errFetch
+		}
+		return &Page[int]{Items: []int{1, 2}}, nil
+	})
+
+	items, err := it.All(context.Background())
+	if !errors.Is(err, errFetch) {
+		t.Errorf("All() error = %v, want %v", err, errFetch)
+	}
+	if len(items) != 2 {
+		t.Errorf("got %d items before the error, want 2", len(items))
+	}
+	if it.Next(context.Background()) {
+		t.Error("Next() after an error returned true")
+	}
+}
+
+func TestWithPage(t *testing.T) {
+	tests := []struct {
+		path string
+		req  PageRequest
+		want string
+	}{
+		{path: "/auth/tokens", req: PageRequest{}, want: "/auth/tokens"},
+		{path: "/auth/tokens", req: PageRequest{Limit: 10, Offset: 20}, want: "/auth/tokens?limit=10&offset=20"},
+		{path: "/runs?pipeline_id=p-1", req: PageRequest{Limit: 10, Cursor: "abc", Offset: 20}, want: "/runs?pipeline_id=p-1&cursor=abc&limit=10"},
+	}
+
+	for _, tt := range tests {
+		if got := withPage(tt.path, tt.req); got != tt.want {
+			t.Errorf("withPage(%q, %+v) = %q, want %q", tt.path, tt.req, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Application types of a pipeline
+const (
+	AppTypeFrontend  = "frontend"
+	AppTypeBackend   = "backend"
+	AppTypeFullstack = "fullstack"
+)
+
+// Pipeline builds and deploys one branch of a repository
+type Pipeline struct {
+	ID              string            `json:"id"`
+	ProjectID       string            `json:"project_id"`
+	TeamID          string            `json:"team_id,omitempty"`
+	Name            string            `json:"name"`
+	AppName         string            `json:"app_name"`
+	Repository      string            `json:"repository"`
+	Branch          string            `json:"branch"`
+	Environment     string            `json:"environment"`
+	AppType         string            `json:"app_type"`
+	BackendPort     int               `json:"backend_port,omitempty"`
+	FrontendEnvVars map[string]string `json:"frontend_env_vars,omitempty"`
+	BackendEnvVars  map[string]string `json:"backend_env_vars,omitempty"`
+	SecretNames     []string          `json:"secret_names,omitempty"` // values are never returned
+	Middleware      []string          `json:"middleware,omitempty"`
+	Status          string            `json:"status"`
+	FrontendURL     string            `json:"frontend_url,omitempty"`
+	BackendURL      string            `json:"backend_url,omitempty"`
+	LastRunID       string            `json:"last_run_id,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// CreatePipelineRequest represents a request to create a pipeline
+type CreatePipelineRequest struct {
+	ProjectID       string            `json:"project_id"`
+	Name            string            `json:"name"`
+	AppName         string            `json:"app_name"` // DNS label, used in the application URLs
+	Repository      string            `json:"repository"`
+	Branch          string            `json:"branch"`
+	Environment     string            `json:"environment,omitempty"`
+	AppType         string            `json:"app_type"`
+	BackendPort     int               `json:"backend_port,omitempty"`
+	FrontendEnvVars map[string]string `json:"frontend_env_vars,omitempty"`
+	BackendEnvVars  map[string]string `json:"backend_env_vars,omitempty"`
+	Secrets         map[string]string `json:"secrets,omitempty"`
+	Middleware      []string          `json:"middleware,omitempty"`
+}
+
+// UpdatePipelineRequest represents a partial update of a pipeline. Nil fields
+// are left unchanged, so an empty Middleware list removes all middleware; env
+// var and secret entries with an empty value are removed. Updating a deployed
+// pipeline restarts the application.
+type UpdatePipelineRequest struct {
+	Name            *string           `json:"name,omitempty"`
+	Branch          *string           `json:"branch,omitempty"`
+	BackendPort     *int              `json:"backend_port,omitempty"`
+	FrontendEnvVars map[string]string `json:"frontend_env_vars,omitempty"`
+	BackendEnvVars  map[string]string `json:"backend_env_vars,omitempty"`
+	Secrets         map[string]string `json:"secrets,omitempty"`
+	Middleware      *[]string         `json:"middleware,omitempty"`
+}
+
+// PipelineFilter selects pipelines to list; empty fields match all pipelines
+type PipelineFilter struct {
+	ProjectID   string
+	Environment string
+	Status      string
+	Repository  string
+	Branch      string
+}
+
+// path returns the list path with the filter as query parameters
+func (f PipelineFilter) path() string {
+	query := url.Values{}
+	for name, value := range map[string]string{
+		"project_id":  f.ProjectID,
+		"environment": f.Environment,
+		"status":      f.Status,
+		"repository":  f.Repository,
+		"branch":      f.Branch,
+	} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	if len(query) == 0 {
+		return "/pipelines"
+	}
+	return "/pipelines?" + query.Encode()
+}
+
+// PipelineListResponse represents a page of pipelines
+type PipelineListResponse struct {
+	Pipelines  []*Pipeline `json:"pipelines"`
+	Total      int         `json:"total"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// ListPipelines lists all pipelines matching the filter, fetching every page
+func (c *Client) ListPipelines(ctx context.Context, filter PipelineFilter) (*PipelineListResponse, error) {
+	pipelines, err := c.IterPipelines(filter, ListOptions{}).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &PipelineListResponse{Pipelines: pipelines, Total: len(pipelines)}, nil
+}
+
+// ListPipelinesPage fetches one page of pipelines matching the filter
+func (c *Client) ListPipelinesPage(ctx context.Context, filter PipelineFilter, req PageRequest) (*PipelineListResponse, error) {
+	var resp PipelineListResponse
+	err := c.Do(ctx, "GET", withPage(filter.path(), req), nil, &resp)
+	return &resp, err
+}
+
+// IterPipelines iterates over the pipelines matching the filter
+func (c *Client) IterPipelines(filter PipelineFilter, opts ListOptions) *Iterator[*Pipeline] {
+	return NewIterator(opts, func(ctx context.Context, req PageRequest) (*Page[*Pipeline], error) {
+		resp, err := c.ListPipelinesPage(ctx, filter, req)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[*Pipeline]{Items: resp.Pipelines, NextCursor: resp.NextCursor, Total: resp.Total}, nil
+	})
+}
+
+// CreatePipeline creates a pipeline in a project
+func (c *Client) CreatePipeline(ctx context.Context, req *CreatePipelineRequest) (*Pipeline, error) {
+	var resp Pipeline
+	err := c.Do(ctx, "POST", "/pipelines", req, &resp)
+	return &resp, err
+}
+
+// GetPipeline gets a specific pipeline
+func (c *Client) GetPipeline(ctx context.Context, pipelineID string) (*Pipeline, error) {
+	var resp Pipeline
+	err := c.Do(ctx, "GET", "/pipelines/"+url.PathEscape(pipelineID), nil, &resp)
+	return &resp, err
+}
+
+// UpdatePipeline updates the configuration of a pipeline
+func (c *Client) UpdatePipeline(ctx context.Context, pipelineID string, req *UpdatePipelineRequest) (*Pipeline, error) {
+	var resp Pipeline
+	err := c.Do(ctx, "PATCH", "/pipelines/"+url.PathEscape(pipelineID), req, &resp)
+	return &resp, err
+}
+
+// DeletePipeline deletes a pipeline and its deployment
+func (c *Client) DeletePipeline(ctx context.Context, pipelineID string) error {
+	return c.Do(ctx, "DELETE", "/pipelines/"+url.PathEscape(pipelineID), nil, nil)
+}
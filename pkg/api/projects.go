@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Project groups the pipelines of an application
+type Project struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	TeamID      string    `json:"team_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CreateProjectRequest represents a request to create a project
+type CreateProjectRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ProjectListResponse represents a page of projects
+type ProjectListResponse struct {
+	Projects   []*Project `json:"projects"`
+	Total      int        `json:"total"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+// ListProjects lists all projects of the active team, fetching every page
+func (c *Client) ListProjects(ctx context.Context) (*ProjectListResponse, error) {
+	projects, err := c.IterProjects(ListOptions{}).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectListResponse{Projects: projects, Total: len(projects)}, nil
+}
+
+// ListProjectsPage fetches one page of projects
+func (c *Client) ListProjectsPage(ctx context.Context, req PageRequest) (*ProjectListResponse, error) {
+	var resp ProjectListResponse
+	err := c.Do(ctx, "GET", withPage("/projects", req), nil, &resp)
+	return &resp, err
+}
+
+// IterProjects iterates over the projects of the active team
+func (c *Client) IterProjects(opts ListOptions) *Iterator[*Project] {
+	return NewIterator(opts, func(ctx context.Context, req PageRequest) (*Page[*Project], error) {
+		resp, err := c.ListProjectsPage(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[*Project]{Items: resp.Projects, NextCursor: resp.NextCursor, Total: resp.Total}, nil
+	})
+}
+
+// CreateProject creates a project in the active team
+func (c *Client) CreateProject(ctx context.Context, req *CreateProjectRequest) (*Project, error) {
+	var resp Project
+	err := c.Do(ctx, "POST", "/projects", req, &resp)
+	return &resp, err
+}
+
+// GetProject gets a specific project
+func (c *Client) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	var resp Project
+	err := c.Do(ctx, "GET", "/projects/"+url.PathEscape(projectID), nil, &resp)
+	return &resp, err
+}
+
+// DeleteProject deletes a project. The platform refuses to delete projects
+// that still have pipelines.
+func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
+	return c.Do(ctx, "DELETE", "/projects/"+url.PathEscape(projectID), nil, nil)
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces secret values in debug output and traces
+const redacted = "[REDACTED]"
+
+// secretFields are JSON fields and query parameters whose values are redacted.
+// "code" is only secret next to "code_verifier" (an authorization code
+// exchange) or in a query string; elsewhere it is an error code.
+var secretFields = map[string]bool{
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"api_token":     true,
+	"device_code":   true,
+	"code_verifier": true,
+	"client_secret": true,
+	"password":      true,
+	"secret":        true,
+}
+
+// secretObjects are JSON fields holding objects whose values are all secret,
+// e.g. the secrets of a pipeline
+var secretObjects = map[string]bool{
+	"secrets": true,
+}
+
+// envFields are JSON fields holding environment variables; the values of
+// variables with sensitive names are redacted
+var envFields = map[string]bool{
+	"env_vars":          true,
+	"frontend_env_vars": true,
+	"backend_env_vars":  true,
+}
+
+// secretNameMarkers are name fragments of sensitive variables
+var secretNameMarkers = []string{"KEY", "SECRET", "TOKEN", "PASSWORD", "CREDENTIAL", "AUTH", "PRIVATE"}
+
+// IsSecretName reports whether a variable name looks sensitive, e.g. API_KEY
+// or JWT_SECRET
+func IsSecretName(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, marker := range secretNameMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// secretHeaders are headers whose values are redacted
+var secretHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+	"X-Api-Key":     true,
+}
+
+var (
+	// bearerPattern matches bearer tokens in non-JSON text
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+	// secretFieldPattern matches secret fields in JSON that cannot be parsed, e.g. truncated bodies
+	secretFieldPattern = regexp.MustCompile(`(?i)("(?:` + strings.Join(secretFieldNames(), "|") + `)"\s*:\s*")[^"]*"?`)
+)
+
+// secretFieldNames returns the names of the secret fields
+func secretFieldNames() []string {
+	names := make([]string, 0, len(secretFields))
+	for name := range secretFields {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	return names
+}
+
+// RedactHeaders returns a copy of the headers with secret values replaced
+func RedactHeaders(header http.Header) http.Header {
+	out := header.Clone()
+	for name := range out {
+		if secretHeaders[http.CanonicalHeaderKey(name)] {
+			for i, v := range out[name] {
+				if scheme, _, ok := strings.Cut(v, " "); ok && strings.EqualFold(scheme, "Bearer") {
+					out[name][i] = scheme + " " + redacted
+				} else {
+					out[name][i] = redacted
+				}
+			}
+		}
+	}
+	return out
+}
+
+// RedactURL returns the URL with secret query parameters replaced
+func RedactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	query := u.Query()
+	for name := range query {
+		if key := strings.ToLower(name); secretFields[key] || key == "code" {
+			for i := range query[name] {
+				query[name][i] = redacted
+			}
+		}
+	}
+	c := *u
+	c.RawQuery = query.Encode()
+	return c.String()
+}
+
+// RedactBody returns the body with the values of secret JSON fields replaced.
+// Bodies that are not JSON have bearer tokens replaced.
+func RedactBody(body []byte) []byte {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		body = secretFieldPattern.ReplaceAll(body, []byte(`${1}`+redacted+`"`))
+		return bearerPattern.ReplaceAll(body, []byte("${1}"+redacted))
+	}
+
+	out, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return []byte(redacted)
+	}
+	return out
+}
+
+// redactValue replaces secret fields in a decoded JSON value
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		_, codeExchange := v["code_verifier"]
+		for name, field := range v {
+			key := strings.ToLower(name)
+			secret := secretFields[key] || (codeExchange && key == "code")
+			if _, isString := field.(string); isString && secret {
+				v[name] = redacted
+			} else if vars, isObject := field.(map[string]interface{}); isObject && (secretObjects[key] || envFields[key]) {
+				for varName, varValue := range vars {
+					if _, isString := varValue.(string); isString && (secretObjects[key] || IsSecretName(varName)) {
+						vars[varName] = redacted
+					}
+				}
+			} else {
+				v[name] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return value
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ResourceRequests(t *testing.T) {
+	var method, uri, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		method, uri, body = r.Method, r.URL.RequestURI(), string(data)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetAuthToken("test-token")
+	ctx := context.Background()
+	branch := "develop"
+
+	tests := []struct {
+		name       string
+		call       func() error
+		wantMethod string
+		wantURI    string
+		wantBody   string
+	}{
+		{
+			name:       "ListProjectsPage",
+			call:       func() error { _, err := client.ListProjectsPage(ctx, PageRequest{Limit: 20}); return err },
+			wantMethod: "GET", wantURI: "/projects?limit=20",
+		},
+		{
+			name:       "CreateProject",
+			call:       func() error { _, err := client.CreateProject(ctx, &CreateProjectRequest{Name: "shop"}); return err },
+			wantMethod: "POST", wantURI: "/projects", wantBody: `{"name":"shop"}`,
+		},
+		{
+			name:       "DeleteProject",
+			call:       func() error { return client.DeleteProject(ctx, "proj-1") },
+			wantMethod: "DELETE", wantURI: "/projects/proj-1",
+		},
+		{
+			name: "ListPipelinesPage",
+			call: func() error {
+				_, err := client.ListPipelinesPage(ctx, PipelineFilter{Repository: "acme/shop", Branch: "main"}, PageRequest{Limit: 10, Cursor: "c2"})
+				return err
+			},
+			wantMethod: "GET", wantURI: "/pipelines?branch=main&repository=acme%2Fshop&cursor=c2&limit=10",
+		},
+		{
+			name: "UpdatePipeline",
+			call: func() error {
+				_, err := client.UpdatePipeline(ctx, "pipe-1", &UpdatePipelineRequest{Branch: &branch, BackendEnvVars: map[string]string{"LOG_LEVEL": ""}})
+				return err
+			},
+			wantMethod: "PATCH", wantURI: "/pipelines/pipe-1", wantBody: `{"branch":"develop","backend_env_vars":{"LOG_LEVEL":""}}`,
+		},
+		{
+			name:       "TriggerRun",
+			call:       func() error { _, err := client.TriggerRun(ctx, "pipe-1", nil); return err },
+			wantMethod: "POST", wantURI: "/pipelines/pipe-1/runs", wantBody: `{}`,
+		},
+		{
+			name: "ListRunsPage",
+			call: func() error {
+				_, err := client.ListRunsPage(ctx, RunFilter{PipelineID: "pipe-1"}, PageRequest{})
+				return err
+			},
+			wantMethod: "GET", wantURI: "/runs?pipeline_id=pipe-1",
+		},
+		{
+			name:       "CancelRun",
+			call:       func() error { _, err := client.CancelRun(ctx, "run-1"); return err },
+			wantMethod: "POST", wantURI: "/runs/run-1/cancel",
+		},
+		{
+			name:       "GetRunLogs",
+			call:       func() error { _, err := client.GetRunLogs(ctx, "run-1", "build"); return err },
+			wantMethod: "GET", wantURI: "/runs/run-1/logs?stage=build",
+		},
+		{
+			name:       "RestartDeployment",
+			call:       func() error { _, err := client.RestartDeployment(ctx, "pipe-1"); return err },
+			wantMethod: "POST", wantURI: "/pipelines/pipe-1/deployment/restart",
+		},
+		{
+			name:       "GetDeploymentEnv",
+			call:       func() error { _, err := client.GetDeploymentEnv(ctx, "pipe-1"); return err },
+			wantMethod: "GET", wantURI: "/pipelines/pipe-1/deployment/env",
+		},
+		{
+			name:       "GetToken escaped ID",
+			call:       func() error { _, err := client.GetToken(ctx, "tok-1/../../teams?x=1"); return err },
+			wantMethod: "GET", wantURI: "/auth/tokens/tok-1%2F..%2F..%2Fteams%3Fx=1",
+		},
+		{
+			name:       "RevokeToken escaped ID",
+			call:       func() error { return client.RevokeToken(ctx, "../me") },
+			wantMethod: "DELETE", wantURI: "/auth/tokens/..%2Fme",
+		},
+		{
+			name:       "GetTeam escaped ID",
+			call:       func() error { _, err := client.GetTeam(ctx, "../auth/tokens"); return err },
+			wantMethod: "GET", wantURI: "/teams/..%2Fauth%2Ftokens",
+		},
+		{
+			name:       "escaped ID",
+			call:       func() error { _, err := client.GetRun(ctx, "../auth/me"); return err },
+			wantMethod: "GET", wantURI: "/runs/..%2Fauth%2Fme",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, uri, body = "", "", ""
+			if err := tt.call(); err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if method != tt.wantMethod || uri != tt.wantURI {
+				t.Errorf("request = %s %s, want %s %s", method, uri, tt.wantMethod, tt.wantURI)
+			}
+			if tt.wantBody != "" && body != tt.wantBody {
+				t.Errorf("body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRun_Finished(t *testing.T) {
+	for status, want := range map[string]bool{
+		StatusPending:   false,
+		StatusRunning:   false,
+		StatusSucceeded: true,
+		StatusFailed:    true,
+		StatusCancelled: true,
+	} {
+		run := &Run{Status: status}
+		if got := run.Finished(); got != want {
+			t.Errorf("Finished() for %s = %v, want %v", status, got, want)
+		}
+	}
+}
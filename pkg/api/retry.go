@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried
+//
+// Requests are retried after network errors and on 408, 429, 500, 502, 503
+// and 504 responses. Idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE)
+// are always retried; other methods only when the context carries an
+// idempotency key (see WithIdempotencyKey).
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts; 1 disables retries
+	MaxAttempts int
+	// InitialBackoff is the base delay before the first retry, doubled for each further retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts
+	MaxBackoff time.Duration
+	// MaxElapsed caps the total time spent on a request, including retries (zero means no cap)
+	MaxElapsed time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by new clients
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		MaxElapsed:     time.Minute,
+	}
+}
+
+// backoff returns the delay before retry n (starting at 1), with jitter:
+// a random duration between half and all of the exponential delay
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.InitialBackoff << (n - 1)
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// IdempotencyKeyHeader carries the idempotency key of a request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context whose requests carry the idempotency key.
+// The server executes requests with the same key at most once, which makes
+// non-idempotent requests such as POST safe to retry.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKey returns the idempotency key of ctx, if any
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// NewIdempotencyKey returns a random idempotency key
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := cryptorand.Read(b); err != nil {
+		// crypto/rand does not fail on supported platforms
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// isIdempotent checks if a request with the method can be repeated safely
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isPermanent checks if a failed attempt must not be retried
+func isPermanent(err error) bool {
+	var versionErr *UnsupportedVersionError
+	return errors.Is(err, ErrUnmatchedRequest) || errors.As(err, &versionErr)
+}
+
+// isRetryableStatus checks if a response status indicates a transient failure
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// SleepContext waits for d or until ctx is done, returning ctx.Err() if ctx
+// is done first
+func SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetries is a retry policy with short delays for tests
+var fastRetries = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+	MaxElapsed:     time.Second,
+}
+
+// flakyServer fails the first n requests with the status and then succeeds
+func flakyServer(t *testing.T, n int32, status int, header http.Header) (*httptest.Server, *atomic.Int32, chan string) {
+	t.Helper()
+	var calls atomic.Int32
+	keys := make(chan string, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys <- r.Header.Get(IdempotencyKeyHeader)
+		if calls.Add(1) <= n {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls, keys
+}
+
+func TestClient_RetriesIdempotentRequests(t *testing.T) {
+	server, calls, _ := flakyServer(t, 2, http.StatusBadGateway, nil)
+
+	var debug bytes.Buffer
+	client := NewClient(server.URL)
+	client.SetRetryPolicy(fastRetries)
+	client.SetDebug(&debug)
+
+	var result map[string]bool
+	if err := client.Do(context.Background(), "GET", "/test", nil, &result); err != nil {
+		t.Fatalf("Do() failed: %v", err)
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+
+	if !result["ok"] {
+		t.Error("Expected the response of the successful attempt")
+	}
+
+	if !strings.Contains(debug.String(), "retrying GET /test") {
+		t.Errorf("Expected retries in debug output, got %q", debug.String())
+	}
+}
+
+func TestClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	server, calls, _ := flakyServer(t, 10, http.StatusServiceUnavailable, nil)
+
+	client := NewClient(server.URL)
+	client.SetRetryPolicy(fastRetries)
+
+	if err := client.Do(context.Background(), "GET", "/test", nil, nil); err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+}
+
+func TestClient_DoesNotRetryPostWithoutIdempotencyKey(t *testing.T) {
+	server, calls, _ := flakyServer(t, 1, http.StatusBadGateway, nil)
+
+	client := NewClient(server.URL)
+	client.SetRetryPolicy(fastRetries)
+
+	if err := client.Do(context.Background(), "POST", "/test", map[string]string{"a": "b"}, nil); err == nil {
+		t.Fatal("Expected error for failed POST")
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestClient_RetriesPostWithIdempotencyKey(t *testing.T) {
+	server, calls, keys := flakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}})
+
+	client := NewClient(server.URL)
+	client.SetRetryPolicy(fastRetries)
+
+	ctx := WithIdempotencyKey(context.Background(), "key-123")
+	if err := client.Do(ctx, "POST", "/test", map[string]string{"a": "b"}, nil); err != nil {
+		t.Fatalf("Do() failed: %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want 2", calls.Load())
+	}
+
+	for i := 0; i < 2; i++ {
+		if key := <-keys; key != "key-123" {
+			t.Errorf("%s = %q, want key-123", IdempotencyKeyHeader, key)
+		}
+	}
+}
+
+func TestClient_RetryRespectsMaxElapsed(t *testing.T) {
+	server, calls, _ := flakyServer(t, 10, http.StatusServiceUnavailable, http.Header{"Retry-After": {"120"}})
+
+	client := NewClient(server.URL)
+	client.SetRetryPolicy(fastRetries)
+
+	start := time.Now()
+	if err := client.Do(context.Background(), "GET", "/test", nil, nil); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	// Waiting 120s would exceed MaxElapsed, so the first response is final
+	if calls.Load() != 1 || time.Since(start) > time.Second {
+		t.Errorf("calls = %d after %s, want 1 without waiting", calls.Load(), time.Since(start))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Mon, 01 Jan 2024 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for n, base := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		for i := 0; i < 20; i++ {
+			d := policy.backoff(n)
+			if d < base/2 || d > base {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", n, d, base/2, base)
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Run and stage states
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+	StatusSkipped   = "skipped"
+)
+
+// Run is one execution of a pipeline
+type Run struct {
+	ID          string     `json:"id"`
+	PipelineID  string     `json:"pipeline_id"`
+	Number      int        `json:"number"`
+	Status      string     `json:"status"`
+	Branch      string     `json:"branch"`
+	CommitSHA   string     `json:"commit_sha"`
+	TriggeredBy string     `json:"triggered_by"`
+	Stages      []Stage    `json:"stages"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// Finished checks if the run has succeeded, failed or been cancelled
+func (r *Run) Finished() bool {
+	return r.Status == StatusSucceeded || r.Status == StatusFailed || r.Status == StatusCancelled
+}
+
+// Stage is a step of a run, e.g. clone, build or deploy
+type Stage struct {
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// TriggerRunRequest represents a request to run a pipeline
+type TriggerRunRequest struct {
+	// CommitSHA is the commit to build; empty builds the head of the branch
+	CommitSHA string `json:"commit_sha,omitempty"`
+}
+
+// StageLog is the log of one stage of a run
+type StageLog struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Log    string `json:"log"`
+}
+
+// RunLogs represents the logs of a run
+type RunLogs struct {
+	RunID  string     `json:"run_id"`
+	Stages []StageLog `json:"stages"`
+}
+
+// RunFilter selects runs to list; empty fields match all runs
+type RunFilter struct {
+	PipelineID string
+	Status     string
+}
+
+// path returns the list path with the filter as query parameters
+func (f RunFilter) path() string {
+	query := url.Values{}
+	if f.PipelineID != "" {
+		query.Set("pipeline_id", f.PipelineID)
+	}
+	if f.Status != "" {
+		query.Set("status", f.Status)
+	}
+	if len(query) == 0 {
+		return "/runs"
+	}
+	return "/runs?" + query.Encode()
+}
+
+// RunListResponse represents a page of runs, most recent first
+type RunListResponse struct {
+	Runs       []*Run `json:"runs"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// TriggerRun starts a run of a pipeline; req may be nil
+func (c *Client) TriggerRun(ctx context.Context, pipelineID string, req *TriggerRunRequest) (*Run, error) {
+	if req == nil {
+		req = &TriggerRunRequest{}
+	}
+	var resp Run
+	err := c.Do(ctx, "POST", "/pipelines/"+url.PathEscape(pipelineID)+"/runs", req, &resp)
+	return &resp, err
+}
+
+// ListRuns lists all runs matching the filter, fetching every page
+func (c *Client) ListRuns(ctx context.Context, filter RunFilter) (*RunListResponse, error) {
+	runs, err := c.IterRuns(filter, ListOptions{}).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &RunListResponse{Runs: runs, Total: len(runs)}, nil
+}
+
+// ListRunsPage fetches one page of runs matching the filter
+func (c *Client) ListRunsPage(ctx context.Context, filter RunFilter, req PageRequest) (*RunListResponse, error) {
+	var resp RunListResponse
+	err := c.Do(ctx, "GET", withPage(filter.path(), req), nil, &resp)
+	return &resp, err
+}
+
+// IterRuns iterates over the runs matching the filter, most recent first
+func (c *Client) IterRuns(filter RunFilter, opts ListOptions) *Iterator[*Run] {
+	return NewIterator(opts, func(ctx context.Context, req PageRequest) (*Page[*Run], error) {
+		resp, err := c.ListRunsPage(ctx, filter, req)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[*Run]{Items: resp.Runs, NextCursor: resp.NextCursor, Total: resp.Total}, nil
+	})
+}
+
+// GetRun gets the current status of a run
+func (c *Client) GetRun(ctx context.Context, runID string) (*Run, error) {
+	var resp Run
+	err := c.Do(ctx, "GET", "/runs/"+url.PathEscape(runID), nil, &resp)
+	return &resp, err
+}
+
+// CancelRun cancels a pending or running run
+func (c *Client) CancelRun(ctx context.Context, runID string) (*Run, error) {
+	var resp Run
+	err := c.Do(ctx, "POST", "/runs/"+url.PathEscape(runID)+"/cancel", nil, &resp)
+	return &resp, err
+}
+
+// GetRunLogs gets the logs of a run; stage limits them to one stage
+func (c *Client) GetRunLogs(ctx context.Context, runID, stage string) (*RunLogs, error) {
+	path := "/runs/" + url.PathEscape(runID) + "/logs"
+	if stage != "" {
+		path += "?stage=" + url.QueryEscape(stage)
+	}
+	var resp RunLogs
+	err := c.Do(ctx, "GET", path, nil, &resp)
+	return &resp, err
+}
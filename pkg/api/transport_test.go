@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCA issues certificates for TLS tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM certificate and key signed by the CA
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeFile writes data to a file in dir and returns its path
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newTLSServer starts a server with a certificate from ca. With clientCAs,
+// the server requires a client certificate signed by it.
+func newTLSServer(t *testing.T, ca *testCA, clientCAs *x509.CertPool) *httptest.Server {
+	t.Helper()
+	certPEM, keyPEM := ca.issue(t, "127.0.0.1", x509.ExtKeyUsageServerAuth)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"developer-platform","version":"1.0.0"}`))
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	if clientCAs != nil {
+		server.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+		server.TLS.ClientCAs = clientCAs
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// getMCPInfo calls the server through a transport built from opts
+func getMCPInfo(t *testing.T, serverURL string, opts TransportOptions) error {
+	t.Helper()
+	transport, err := NewTransport(opts)
+	if err != nil {
+		t.Fatalf("NewTransport() failed: %v", err)
+	}
+	client := NewClient(serverURL)
+	client.SetTransport(transport)
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+	_, err = client.GetMCPInfo(context.Background())
+	return err
+}
+
+func TestNewTransport_CustomCA(t *testing.T) {
+	ca := newTestCA(t)
+	server := newTLSServer(t, ca, nil)
+	caFile := writeFile(t, t.TempDir(), "ca.pem", ca.pem)
+
+	if err := getMCPInfo(t, server.URL, TransportOptions{}); err == nil {
+		t.Error("Request to a server with an unknown CA succeeded")
+	}
+	if err := getMCPInfo(t, server.URL, TransportOptions{CAFile: caFile}); err != nil {
+		t.Errorf("Request with CA file failed: %v", err)
+	}
+	if err := getMCPInfo(t, server.URL, TransportOptions{InsecureSkipVerify: true}); err != nil {
+		t.Errorf("Insecure request failed: %v", err)
+	}
+}
+
+func TestNewTransport_ClientCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	server := newTLSServer(t, ca, clientCAs)
+
+	dir := t.TempDir()
+	caFile := writeFile(t, dir, "ca.pem", ca.pem)
+	certPEM, keyPEM := ca.issue(t, "chrono-cli", x509.ExtKeyUsageClientAuth)
+	certFile := writeFile(t, dir, "client.pem", certPEM)
+	keyFile := writeFile(t, dir, "client-key.pem", keyPEM)
+
+	if err := getMCPInfo(t, server.URL, TransportOptions{CAFile: caFile}); err == nil {
+		t.Error("Request without a client certificate succeeded")
+	}
+	opts := TransportOptions{CAFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile}
+	if err := getMCPInfo(t, server.URL, opts); err != nil {
+		t.Errorf("Request with a client certificate failed: %v", err)
+	}
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"developer-platform"}`))
+	}))
+	defer proxy.Close()
+
+	if err := getMCPInfo(t, "http://platform.internal.example/api/v1", TransportOptions{Proxy: proxy.URL}); err != nil {
+		t.Fatalf("Request through proxy failed: %v", err)
+	}
+	if proxied != "http://platform.internal.example/api/v1/mcp/info" {
+		t.Errorf("Proxy received %q", proxied)
+	}
+}
+
+func TestNewTransport_InvalidOptions(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := writeFile(t, dir, "ca.pem", []byte("not a certificate"))
+
+	tests := []struct {
+		name string
+		opts TransportOptions
+	}{
+		{name: "missing CA file", opts: TransportOptions{CAFile: filepath.Join(dir, "missing.pem")}},
+		{name: "CA file without certificates", opts: TransportOptions{CAFile: notPEM}},
+		{name: "certificate without key", opts: TransportOptions{ClientCertFile: notPEM}},
+		{name: "invalid client certificate", opts: TransportOptions{ClientCertFile: notPEM, ClientKeyFile: notPEM}},
+		{name: "invalid proxy", opts: TransportOptions{Proxy: "::"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTransport(tt.opts); err == nil {
+				t.Error("NewTransport() succeeded, want error")
+			}
+		})
+	}
+}
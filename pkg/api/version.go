@@ -0,0 +1,193 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Headers the platform uses to advertise the CLI versions it supports
+const (
+	MinCLIVersionHeader         = "X-Chrono-Min-CLI-Version"
+	RecommendedCLIVersionHeader = "X-Chrono-Recommended-CLI-Version"
+)
+
+// userAgentProduct is the product name sent in the User-Agent header
+const userAgentProduct = "chrono-cli"
+
+// UserAgent returns the User-Agent sent by a CLI of the given version,
+// e.g. "chrono-cli/1.4.0 (linux; amd64) go1.22.5"
+func UserAgent(version string) string {
+	if version == "" {
+		version = "dev"
+	}
+	return fmt.Sprintf("%s/%s (%s; %s) %s", userAgentProduct, version, runtime.GOOS, runtime.GOARCH, runtime.Version())
+}
+
+// Compatibility is the range of CLI versions a platform supports
+type Compatibility struct {
+	// MinVersion is the oldest CLI version the platform accepts
+	MinVersion string
+	// RecommendedVersion is the version users should upgrade to
+	RecommendedVersion string
+}
+
+// compatibilityFromHeader reads the advertised versions from response headers
+func compatibilityFromHeader(header http.Header) Compatibility {
+	return Compatibility{
+		MinVersion:         header.Get(MinCLIVersionHeader),
+		RecommendedVersion: header.Get(RecommendedCLIVersionHeader),
+	}
+}
+
+// VersionStatus is the result of checking a CLI version against a Compatibility
+type VersionStatus int
+
+const (
+	// VersionUnknown means the CLI is a development build or the platform
+	// advertises no versions
+	VersionUnknown VersionStatus = iota
+	// VersionSupported means the CLI is at or above the recommended version
+	VersionSupported
+	// VersionOutdated means the CLI works but an upgrade is recommended
+	VersionOutdated
+	// VersionUnsupported means the CLI is older than the minimum version
+	VersionUnsupported
+)
+
+// Check compares a CLI version to the supported range. Development builds
+// and versions that are not semantic versions are never reported as outdated.
+func (c Compatibility) Check(version string) VersionStatus {
+	if _, err := parseVersion(version); err != nil {
+		return VersionUnknown
+	}
+	if c.MinVersion != "" {
+		if cmp, err := CompareVersions(version, c.MinVersion); err == nil && cmp < 0 {
+			return VersionUnsupported
+		}
+	}
+	if c.RecommendedVersion != "" {
+		if cmp, err := CompareVersions(version, c.RecommendedVersion); err == nil && cmp < 0 {
+			return VersionOutdated
+		}
+	}
+	if c.MinVersion == "" && c.RecommendedVersion == "" {
+		return VersionUnknown
+	}
+	return VersionSupported
+}
+
+// UnsupportedVersionError is returned when the platform no longer accepts
+// the CLI version
+type UnsupportedVersionError struct {
+	Version    string
+	MinVersion string
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	if e.MinVersion == "" {
+		return fmt.Sprintf("chrono %s is no longer supported by the platform", e.Version)
+	}
+	return fmt.Sprintf("chrono %s is no longer supported by the platform (minimum version %s)", e.Version, e.MinVersion)
+}
+
+// CompareVersions compares two semantic versions, ignoring a leading "v" and
+// build metadata. It returns -1, 0 or 1; pre-releases sort before releases.
+func CompareVersions(a, b string) (int, error) {
+	va, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range va.core {
+		if va.core[i] != vb.core[i] {
+			if va.core[i] < vb.core[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	switch {
+	case va.pre == vb.pre:
+		return 0, nil
+	case va.pre == "":
+		return 1, nil
+	case vb.pre == "":
+		return -1, nil
+	}
+	return comparePrerelease(va.pre, vb.pre), nil
+}
+
+// comparePrerelease compares pre-release versions as semver section 11
+// specifies: identifier by identifier, numeric identifiers as numbers and
+// below alphanumeric ones, and a shorter list first when all else is equal.
+// E.g. rc.9 < rc.10 < rc.beta.
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if cmp := comparePrereleaseIdentifier(as[i], bs[i]); cmp != 0 {
+			return cmp
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// comparePrereleaseIdentifier compares two dot-separated pre-release identifiers
+func comparePrereleaseIdentifier(a, b string) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		if na == nb {
+			return 0
+		}
+		if na < nb {
+			return -1
+		}
+		return 1
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// semver is a parsed semantic version
+type semver struct {
+	core [3]int
+	pre  string
+}
+
+// parseVersion parses "v1.2.3", "1.2.3-rc.1" or "1.2" (patch 0)
+func parseVersion(s string) (semver, error) {
+	var v semver
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, v.pre, _ = strings.Cut(s, "-")
+
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return v, fmt.Errorf("invalid version %q", s)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid version %q", s)
+		}
+		v.core[i] = n
+	}
+	return v, nil
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.2.3", b: "1.2.3", want: 0},
+		{a: "v1.2.3", b: "1.2.3", want: 0},
+		{a: "1.2", b: "1.2.0", want: 0},
+		{a: "1.2.3+build.5", b: "1.2.3", want: 0},
+		{a: "1.2.3", b: "1.10.0", want: -1},
+		{a: "2.0.0", b: "1.99.99", want: 1},
+		{a: "1.2.3-rc.1", b: "1.2.3", want: -1},
+		{a: "1.2.3-rc.2", b: "1.2.3-rc.1", want: 1},
+		{a: "1.2.0-rc.10", b: "1.2.0-rc.9", want: 1},
+		{a: "1.2.0-rc.9", b: "1.2.0-rc.10", want: -1},
+		{a: "1.0.0-alpha", b: "1.0.0-alpha.1", want: -1},
+		{a: "1.0.0-alpha.1", b: "1.0.0-alpha.beta", want: -1},
+		{a: "1.0.0-beta.11", b: "1.0.0-beta.2", want: 1},
+		{a: "1.0.0-rc.1", b: "1.0.0-rc.1+build.5", want: 0},
+	}
+
+	for _, tt := range tests {
+		got, err := CompareVersions(tt.a, tt.b)
+		if err != nil {
+			t.Errorf("CompareVersions(%q, %q) failed: %v", tt.a, tt.b, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{"", "dev", "1", "1.x.0", "1.2.3.4"} {
+		if _, err := CompareVersions(invalid, "1.0.0"); err == nil {
+			t.Errorf("CompareVersions(%q) succeeded, want error", invalid)
+		}
+	}
+}
+
+func TestCompatibility_Check(t *testing.T) {
+	compat := Compatibility{MinVersion: "1.2.0", RecommendedVersion: "1.5.0"}
+
+	tests := []struct {
+		compat  Compatibility
+		version string
+		want    VersionStatus
+	}{
+		{compat: compat, version: "1.1.9", want: VersionUnsupported},
+		{compat: compat, version: "1.2.0", want: VersionOutdated},
+		{compat: compat, version: "1.5.0", want: VersionSupported},
+		{compat: compat, version: "2.0.0", want: VersionSupported},
+		{compat: compat, version: "dev", want: VersionUnknown},
+		{compat: Compatibility{}, version: "1.0.0", want: VersionUnknown},
+		{compat: Compatibility{MinVersion: "1.0.0"}, version: "1.0.0", want: VersionSupported},
+		{compat: Compatibility{MinVersion: "1.2.0-rc.9"}, version: "1.2.0-rc.10", want: VersionSupported},
+		{compat: Compatibility{RecommendedVersion: "1.2.0-rc.10"}, version: "1.2.0-rc.9", want: VersionOutdated},
+	}
+
+	for _, tt := range tests {
+		if got := tt.compat.Check(tt.version); got != tt.want {
+			t.Errorf("%+v.Check(%q) = %d, want %d", tt.compat, tt.version, got, tt.want)
+		}
+	}
+}
+
+// versionServer advertises the given CLI versions on every response
+func versionServer(t *testing.T, status int, compat Compatibility, userAgent *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*userAgent = r.UserAgent()
+		w.Header().Set(MinCLIVersionHeader, compat.MinVersion)
+		w.Header().Set(RecommendedCLIVersionHeader, compat.RecommendedVersion)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"name":"developer-platform","version":"1.0.0"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_SendsUserAgent(t *testing.T) {
+	var userAgent string
+	server := versionServer(t, http.StatusOK, Compatibility{}, &userAgent)
+
+	client := NewClient(server.URL)
+	if _, err := client.GetMCPInfo(context.Background()); err != nil {
+		t.Fatalf("GetMCPInfo() failed: %v", err)
+	}
+	if !strings.HasPrefix(userAgent, "chrono-cli/dev (") {
+		t.Errorf("User-Agent = %q, want chrono-cli/dev", userAgent)
+	}
+
+	client.SetVersion("1.4.0")
+	if _, err := client.GetMCPInfo(context.Background()); err != nil {
+		t.Fatalf("GetMCPInfo() failed: %v", err)
+	}
+	if !strings.HasPrefix(userAgent, "chrono-cli/1.4.0 (") {
+		t.Errorf("User-Agent = %q, want chrono-cli/1.4.0", userAgent)
+	}
+}
+
+func TestClient_WarnsOnceWhenOutdated(t *testing.T) {
+	var userAgent string
+	server := versionServer(t, http.StatusOK, Compatibility{MinVersion: "1.0.0", RecommendedVersion: "1.5.0"}, &userAgent)
+
+	var warnings atomic.Int32
+	client := NewClient(server.URL)
+	client.SetVersion("1.4.0")
+	client.OnVersionWarning(func(version string, compat Compatibility) {
+		warnings.Add(1)
+		if version != "1.4.0" || compat.RecommendedVersion != "1.5.0" {
+			t.Errorf("warning for %q with %+v", version, compat)
+		}
+	})
+
+	for range 3 {
+		if _, err := client.GetMCPInfo(context.Background()); err != nil {
+			t.Fatalf("GetMCPInfo() failed: %v", err)
+		}
+	}
+	if n := warnings.Load(); n != 1 {
+		t.Errorf("warned %d times, want 1", n)
+	}
+}
+
+func TestClient_RejectsUnsupportedVersion(t *testing.T) {
+	var userAgent string
+	compat := Compatibility{MinVersion: "2.0.0"}
+
+	tests := []struct {
+		name    string
+		status  int
+		version string
+	}{
+		{name: "below advertised minimum", status: http.StatusOK, version: "1.4.0"},
+		{name: "upgrade required", status: http.StatusUpgradeRequired, version: "dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := versionServer(t, tt.status, compat, &userAgent)
+			client := NewClient(server.URL)
+			client.SetVersion(tt.version)
+
+			_, err := client.GetMCPInfo(context.Background())
+			var versionErr *UnsupportedVersionError
+			if !errors.As(err, &versionErr) {
+				t.Fatalf("GetMCPInfo() error = %v, want UnsupportedVersionError", err)
+			}
+			if versionErr.Version != tt.version || versionErr.MinVersion != "2.0.0" {
+				t.Errorf("error = %+v", versionErr)
+			}
+		})
+	}
+}
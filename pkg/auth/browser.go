@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// ErrBrowserUnavailable is returned when no browser can be opened on this machine
+var ErrBrowserUnavailable = errors.New("no browser available")
+
+// OpenBrowser opens the URL in the user's default browser
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		// Headless sessions (SSH, containers) have no display to open a browser on
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return ErrBrowserUnavailable
+		}
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return errors.Join(ErrBrowserUnavailable, err)
+	}
+
+	// Reap the process in the background so it doesn't linger as a zombie
+	go cmd.Wait()
+	return nil
+}
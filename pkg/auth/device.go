@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+)
+
+// Device flow poll states (RFC 8628 section 3.5)
+const (
+	StatusAuthorizationPending = "authorization_pending"
+	StatusSlowDown             = "slow_down"
+	StatusAccessDenied         = "access_denied"
+	StatusExpiredToken         = "expired_token"
+)
+
+const (
+	// defaultPollInterval is used when the server does not send an interval
+	defaultPollInterval = 5 * time.Second
+	// slowDownIncrement is added to the interval for every slow_down response
+	slowDownIncrement = 5 * time.Second
+	// maxBackoff caps the interval after repeated network errors
+	maxBackoff = time.Minute
+	// defaultMaxNetworkErrors is the number of consecutive network errors tolerated
+	defaultMaxNetworkErrors = 5
+)
+
+// Terminal states of the device flow, other than success
+var (
+	ErrAccessDenied = errors.New("authorization request was denied")
+	ErrExpiredToken = errors.New("device code expired before authorization completed")
+	ErrCancelled    = errors.New("authentication cancelled")
+)
+
+// DeviceFlowError is returned when the server answers with an unexpected error code
+type DeviceFlowError struct {
+	Code        string
+	Description string
+}
+
+func (e *DeviceFlowError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("device flow failed: %s (%s)", e.Code, e.Description)
+	}
+	return fmt.Sprintf("device flow failed: %s", e.Code)
+}
+
+// DeviceFlowClient is the part of the API client used by the device flow
+type DeviceFlowClient interface {
+	StartDeviceFlow(ctx context.Context) (*api.DeviceFlowStartResponse, error)
+	PollDeviceFlow(ctx context.Context, deviceCode string) (*api.DeviceFlowPollResponse, error)
+}
+
+// DeviceFlow runs the OAuth device authorization grant (RFC 8628)
+//
+// Call Start to obtain the user code, show it to the user, then call Wait to
+// poll until the request is approved, denied, expires or ctx is cancelled.
+type DeviceFlow struct {
+	Client DeviceFlowClient
+	// OnPending is called after every poll that is still pending (optional)
+	OnPending func()
+	// MaxNetworkErrors is the number of consecutive failed polls tolerated
+	// before giving up (defaults to 5)
+	MaxNetworkErrors int
+	// sleep waits between polls; replaced in tests
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// Start requests a device code and user code from the server
+func (f *DeviceFlow) Start(ctx context.Context) (*api.DeviceFlowStartResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCancelled, err)
+	}
+
+	resp, err := f.Client.StartDeviceFlow(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start device flow: %w", err)
+	}
+	if resp.DeviceCode == "" || resp.UserCode == "" {
+		return nil, fmt.Errorf("failed to start device flow: server did not return a device code")
+	}
+	return resp, nil
+}
+
+// Wait polls the server until the device flow reaches a terminal state.
+// It returns the tokens on approval, ErrAccessDenied, ErrExpiredToken,
+// ErrCancelled when ctx is done, or a *DeviceFlowError for unknown error codes.
+func (f *DeviceFlow) Wait(ctx context.Context, start *api.DeviceFlowStartResponse) (*api.LoginResponse, error) {
+	sleep := f.sleep
+	if sleep == nil {
+		sleep = api.SleepContext
+	}
+	maxNetworkErrors := f.MaxNetworkErrors
+	if maxNetworkErrors <= 0 {
+		maxNetworkErrors = defaultMaxNetworkErrors
+	}
+
+	interval := time.Duration(start.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	var deadline time.Time
+	if start.ExpiresIn > 0 {
+		deadline = time.Now().Add(time.Duration(start.ExpiresIn) * time.Second)
+	}
+
+	networkErrors := 0
+	wait := interval
+	for {
+		if !deadline.IsZero() && time.Now().Add(wait).After(deadline) {
+			return nil, ErrExpiredToken
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCancelled, err)
+		}
+
+		resp, err := f.Client.PollDeviceFlow(ctx, start.DeviceCode)
+		if err != nil && ctx.Err() != nil {
+			// The poll was interrupted, not a network error
+			return nil, fmt.Errorf("%w: %w", ErrCancelled, ctx.Err())
+		}
+		status := ""
+		if resp != nil {
+			status = resp.Status
+		}
+
+		// Requests that failed without an OAuth error code are treated as
+		// transient and retried with exponential backoff
+		if err != nil && status == "" {
+			networkErrors++
+			if networkErrors >= maxNetworkErrors {
+				return nil, fmt.Errorf("failed to poll device flow: %w", err)
+			}
+			wait = backoff(interval, networkErrors)
+			continue
+		}
+		networkErrors = 0
+		wait = interval
+
+		switch status {
+		case StatusAuthorizationPending:
+			if f.OnPending != nil {
+				f.OnPending()
+			}
+		case StatusSlowDown:
+			// The increase applies to all subsequent polls (RFC 8628 section 3.5)
+			interval += slowDownIncrement
+			wait = interval
+			if f.OnPending != nil {
+				f.OnPending()
+			}
+		case StatusAccessDenied:
+			return nil, ErrAccessDenied
+		case StatusExpiredToken:
+			return nil, ErrExpiredToken
+		default:
+			if resp.AccessToken != "" {
+				return &api.LoginResponse{
+					AccessToken:  resp.AccessToken,
+					RefreshToken: resp.RefreshToken,
+					ExpiresIn:    resp.ExpiresIn,
+					User:         resp.User,
+				}, nil
+			}
+			if status == "" {
+				return nil, &DeviceFlowError{Code: "invalid_response", Description: "server returned no access token"}
+			}
+			return nil, &DeviceFlowError{Code: status, Description: resp.ErrorDescription}
+		}
+	}
+}
+
+// backoff returns the exponential backoff after n consecutive failures
+func backoff(interval time.Duration, n int) time.Duration {
+	d := interval << n
+	if d <= 0 || d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
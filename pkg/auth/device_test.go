@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+)
+
+// scriptedResponse is one poll response from the fake server
+type scriptedResponse struct {
+	status int
+	body   interface{}
+}
+
+// newDeviceFlowServer starts a fake server that answers polls from a script
+func newDeviceFlowServer(t *testing.T, interval int, script []scriptedResponse) *httptest.Server {
+	var mu sync.Mutex
+	var polls int
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/device/start":
+			json.NewEncoder(w).Encode(api.DeviceFlowStartResponse{
+				DeviceCode:      "test-device-code",
+				UserCode:        "ABCD1234",
+				VerificationURI: "https://example.com/device",
+				ExpiresIn:       600,
+				Interval:        interval,
+			})
+		case "/auth/device/poll":
+			mu.Lock()
+			defer mu.Unlock()
+
+			if polls >= len(script) {
+				t.Errorf("Unexpected poll #%d", polls+1)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			resp := script[polls]
+			polls++
+
+			w.WriteHeader(resp.status)
+			json.NewEncoder(w).Encode(resp.body)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+// runDeviceFlow runs the flow against the server, recording the poll intervals
+func runDeviceFlow(ctx context.Context, serverURL string) (*api.LoginResponse, []time.Duration, error) {
+	var sleeps []time.Duration
+	flow := &DeviceFlow{
+		Client: api.NewClient(serverURL),
+		sleep: func(ctx context.Context, d time.Duration) error {
+			sleeps = append(sleeps, d)
+			return ctx.Err()
+		},
+	}
+
+	start, err := flow.Start(ctx)
+	if err != nil {
+		return nil, sleeps, err
+	}
+	resp, err := flow.Wait(ctx, start)
+	return resp, sleeps, err
+}
+
+var (
+	pending  = scriptedResponse{http.StatusBadRequest, map[string]string{"error": StatusAuthorizationPending}}
+	slowDown = scriptedResponse{http.StatusBadRequest, map[string]string{"error": StatusSlowDown}}
+	approved = scriptedResponse{http.StatusOK, map[string]interface{}{
+		"access_token":  "test-access-token",
+		"refresh_token": "test-refresh-token",
+		"expires_in":    3600,
+		"user":          map[string]string{"email": "test@example.com"},
+	}}
+)
+
+func TestDeviceFlow_Approved(t *testing.T) {
+	// The platform also reports pending with a 202 status body
+	pendingAccepted := scriptedResponse{http.StatusAccepted, map[string]string{"status": StatusAuthorizationPending}}
+
+	server := newDeviceFlowServer(t, 5, []scriptedResponse{pending, pendingAccepted, approved})
+	defer server.Close()
+
+	resp, sleeps, err := runDeviceFlow(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Wait() failed: %v", err)
+	}
+
+	if resp.AccessToken != "test-access-token" || resp.RefreshToken != "test-refresh-token" {
+		t.Errorf("Unexpected tokens: %+v", resp)
+	}
+
+	if len(sleeps) != 3 {
+		t.Errorf("Expected 3 polls, got %d", len(sleeps))
+	}
+}
+
+func TestDeviceFlow_SlowDownIncreasesIntervalPermanently(t *testing.T) {
+	server := newDeviceFlowServer(t, 5, []scriptedResponse{pending, slowDown, pending, slowDown, approved})
+	defer server.Close()
+
+	_, sleeps, err := runDeviceFlow(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Wait() failed: %v", err)
+	}
+
+	want := []time.Duration{5 * time.Second, 5 * time.Second, 10 * time.Second, 10 * time.Second, 15 * time.Second}
+	if len(sleeps) != len(want) {
+		t.Fatalf("Intervals = %v, want %v", sleeps, want)
+	}
+	for i := range want {
+		if sleeps[i] != want[i] {
+			t.Errorf("Intervals = %v, want %v", sleeps, want)
+			break
+		}
+	}
+}
+
+func TestDeviceFlow_TerminalErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  scriptedResponse
+		expectErr error
+	}{
+		{
+			name:      "access denied",
+			response:  scriptedResponse{http.StatusForbidden, map[string]string{"error": StatusAccessDenied}},
+			expectErr: ErrAccessDenied,
+		},
+		{
+			name:      "expired token",
+			response:  scriptedResponse{http.StatusGone, map[string]string{"error": StatusExpiredToken}},
+			expectErr: ErrExpiredToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newDeviceFlowServer(t, 5, []scriptedResponse{pending, tt.response})
+			defer server.Close()
+
+			resp, _, err := runDeviceFlow(context.Background(), server.URL)
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("Expected %v, got %v", tt.expectErr, err)
+			}
+			if resp != nil {
+				t.Error("Expected no tokens on failure")
+			}
+		})
+	}
+}
+
+func TestDeviceFlow_UnknownErrorCode(t *testing.T) {
+	server := newDeviceFlowServer(t, 5, []scriptedResponse{
+		{http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "device code unknown"}},
+	})
+	defer server.Close()
+
+	_, _, err := runDeviceFlow(context.Background(), server.URL)
+
+	var flowErr *DeviceFlowError
+	if !errors.As(err, &flowErr) {
+		t.Fatalf("Expected *DeviceFlowError, got %v", err)
+	}
+	if flowErr.Code != "invalid_grant" {
+		t.Errorf("Code = %v, want invalid_grant", flowErr.Code)
+	}
+}
+
+func TestDeviceFlow_EmptyTokenIsNotSuccess(t *testing.T) {
+	server := newDeviceFlowServer(t, 5, []scriptedResponse{
+		{http.StatusOK, map[string]string{"status": "complete"}},
+	})
+	defer server.Close()
+
+	_, _, err := runDeviceFlow(context.Background(), server.URL)
+	if err == nil {
+		t.Error("Expected error for response without access token")
+	}
+}
+
+func TestDeviceFlow_NetworkErrorsBackOff(t *testing.T) {
+	serverError := scriptedResponse{http.StatusBadGateway, "upstream unavailable"}
+
+	server := newDeviceFlowServer(t, 5, []scriptedResponse{serverError, serverError, approved})
+	defer server.Close()
+
+	_, sleeps, err := runDeviceFlow(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Wait() failed: %v", err)
+	}
+
+	want := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second}
+	for i := range want {
+		if i >= len(sleeps) || sleeps[i] != want[i] {
+			t.Errorf("Intervals = %v, want %v", sleeps, want)
+			break
+		}
+	}
+}
+
+func TestDeviceFlow_Cancelled(t *testing.T) {
+	server := newDeviceFlowServer(t, 5, []scriptedResponse{pending})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	flow := &DeviceFlow{
+		Client: api.NewClient(server.URL),
+		OnPending: func() {
+			// Simulate Ctrl-C while waiting for the user
+			cancel()
+		},
+	}
+
+	start, err := flow.Start(ctx)
+	if err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	flow.sleep = func(ctx context.Context, d time.Duration) error {
+		return ctx.Err()
+	}
+
+	_, err = flow.Wait(ctx, start)
+	if !errors.Is(err, ErrCancelled) {
+		t.Errorf("Expected ErrCancelled, got %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
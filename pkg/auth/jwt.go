@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Claims holds the JWT claims shown to the user
+//
+// Claims are decoded without verifying the signature. They are for display
+// only; the server remains the authority on whether a token is valid.
+type Claims struct {
+	Issuer    string    `json:"iss,omitempty"`
+	Subject   string    `json:"sub,omitempty"`
+	Audience  []string  `json:"aud,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	Roles     []string  `json:"roles,omitempty"`
+	IssuedAt  time.Time `json:"iat,omitempty"`
+	ExpiresAt time.Time `json:"exp,omitempty"`
+}
+
+// rawClaims mirrors the JWT payload, including Keycloak role claims
+type rawClaims struct {
+	Issuer      string          `json:"iss"`
+	Subject     string          `json:"sub"`
+	Audience    json.RawMessage `json:"aud"`
+	Email       string          `json:"email"`
+	Roles       []string        `json:"roles"`
+	IssuedAt    float64         `json:"iat"`
+	ExpiresAt   float64         `json:"exp"`
+	RealmAccess struct {
+		Roles []string `json:"roles"`
+	} `json:"realm_access"`
+}
+
+// IsJWT checks if a token has the three-part structure of a JWT
+func IsJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// ParseClaims decodes the payload of a JWT without verifying its signature
+func ParseClaims(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JWT payload: %w", err)
+	}
+
+	var raw rawClaims
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse JWT claims: %w", err)
+	}
+
+	claims := &Claims{
+		Issuer:  raw.Issuer,
+		Subject: raw.Subject,
+		Email:   raw.Email,
+		Roles:   append(raw.Roles, raw.RealmAccess.Roles...),
+	}
+
+	// "aud" may be a single string or an array (RFC 7519 section 4.1.3)
+	if len(raw.Audience) > 0 {
+		var single string
+		if err := json.Unmarshal(raw.Audience, &single); err == nil {
+			claims.Audience = []string{single}
+		} else if err := json.Unmarshal(raw.Audience, &claims.Audience); err != nil {
+			return nil, fmt.Errorf("failed to parse JWT audience: %w", err)
+		}
+	}
+
+	if raw.IssuedAt > 0 {
+		claims.IssuedAt = time.Unix(int64(raw.IssuedAt), 0)
+	}
+	if raw.ExpiresAt > 0 {
+		claims.ExpiresAt = time.Unix(int64(raw.ExpiresAt), 0)
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+// makeJWT builds an unsigned JWT with the given JSON payload
+func makeJWT(payload string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	body := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return header + "." + body + ".signature"
+}
+
+func TestParseClaims(t *testing.T) {
+	token := makeJWT(`{
+		"iss": "https://auth.example.com/realms/platform",
+		"sub": "user-123",
+		"aud": "chrono-cli",
+		"email": "test@example.com",
+		"iat": 1700000000,
+		"exp": 1700003600,
+		"realm_access": {"roles": ["developer", "offline_access"]}
+	}`)
+
+	claims, err := ParseClaims(token)
+	if err != nil {
+		t.Fatalf("ParseClaims() failed: %v", err)
+	}
+
+	if claims.Issuer != "https://auth.example.com/realms/platform" {
+		t.Errorf("Issuer = %v", claims.Issuer)
+	}
+
+	if len(claims.Audience) != 1 || claims.Audience[0] != "chrono-cli" {
+		t.Errorf("Audience = %v, want [chrono-cli]", claims.Audience)
+	}
+
+	if len(claims.Roles) != 2 || claims.Roles[0] != "developer" {
+		t.Errorf("Roles = %v, want [developer offline_access]", claims.Roles)
+	}
+
+	if !claims.ExpiresAt.Equal(time.Unix(1700003600, 0)) {
+		t.Errorf("ExpiresAt = %v", claims.ExpiresAt)
+	}
+}
+
+func TestParseClaims_AudienceArray(t *testing.T) {
+	claims, err := ParseClaims(makeJWT(`{"aud": ["platform", "chrono-cli"], "roles": ["admin"]}`))
+	if err != nil {
+		t.Fatalf("ParseClaims() failed: %v", err)
+	}
+
+	if len(claims.Audience) != 2 {
+		t.Errorf("Audience = %v, want 2 entries", claims.Audience)
+	}
+
+	if len(claims.Roles) != 1 || claims.Roles[0] != "admin" {
+		t.Errorf("Roles = %v, want [admin]", claims.Roles)
+	}
+}
+
+func TestParseClaims_NotJWT(t *testing.T) {
+	if _, err := ParseClaims("dp_opaque_api_token"); err == nil {
+		t.Error("Expected error for non-JWT token")
+	}
+
+	if IsJWT("dp_opaque_api_token") {
+		t.Error("Expected IsJWT() to be false for API token")
+	}
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultClientID is the OAuth client ID registered for Chrono CLI
+const DefaultClientID = "chrono-cli"
+
+// callbackPath is the path of the redirect URI served by the loopback server
+const callbackPath = "/callback"
+
+// LoopbackFlow performs the OAuth authorization-code flow with PKCE (RFC 8252).
+// A short-lived HTTP server on 127.0.0.1 receives the redirect from the browser.
+type LoopbackFlow struct {
+	// AuthorizeURL is the authorization endpoint of the platform
+	AuthorizeURL string
+	// ClientID is the OAuth client ID (defaults to DefaultClientID)
+	ClientID string
+	// OpenBrowser opens the authorization URL (defaults to OpenBrowser).
+	// Returning ErrBrowserUnavailable lets callers fall back to the device flow.
+	OpenBrowser func(url string) error
+	// Timeout limits how long to wait for the user to finish in the browser
+	Timeout time.Duration
+}
+
+// AuthorizationCode is the result of a successful loopback authorization
+type AuthorizationCode struct {
+	Code         string
+	CodeVerifier string
+	RedirectURI  string
+	ClientID     string
+}
+
+// callbackResult carries the outcome of the redirect to the loopback server
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// Authorize opens the browser and waits for the authorization code
+func (f *LoopbackFlow) Authorize(ctx context.Context) (*AuthorizationCode, error) {
+	clientID := f.ClientID
+	if clientID == "" {
+		clientID = DefaultClientID
+	}
+	openBrowser := f.OpenBrowser
+	if openBrowser == nil {
+		openBrowser = OpenBrowser
+	}
+	if f.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.Timeout)
+		defer cancel()
+	}
+
+	pkce, err := NewPKCE()
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	// Listen on an ephemeral loopback port only
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start local callback server: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s%s", listener.Addr().String(), callbackPath)
+
+	results := make(chan callbackResult, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		result := parseCallback(r.URL.Query(), state)
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if result.err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, callbackPage, "Authentication failed", "Return to your terminal for details.")
+		} else {
+			fmt.Fprintf(w, callbackPage, "Authentication complete", "You can close this window and return to your terminal.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL, err := buildAuthorizeURL(f.AuthorizeURL, clientID, redirectURI, state, pkce)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := openBrowser(authURL); err != nil {
+		if errors.Is(err, ErrBrowserUnavailable) {
+			return nil, err
+		}
+		return nil, errors.Join(ErrBrowserUnavailable, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out waiting for browser authentication")
+		}
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return &AuthorizationCode{
+			Code:         result.code,
+			CodeVerifier: pkce.Verifier,
+			RedirectURI:  redirectURI,
+			ClientID:     clientID,
+		}, nil
+	}
+}
+
+// buildAuthorizeURL adds the authorization request parameters to the endpoint
+func buildAuthorizeURL(endpoint, clientID, redirectURI, state string, pkce *PKCE) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization URL: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", clientID)
+	q.Set("redirect_uri", redirectURI)
+	q.Set("state", state)
+	q.Set("code_challenge", pkce.Challenge)
+	q.Set("code_challenge_method", pkce.Method)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// parseCallback validates the redirect parameters and extracts the code
+func parseCallback(q url.Values, wantState string) callbackResult {
+	if q.Get("state") != wantState {
+		return callbackResult{err: fmt.Errorf("authorization response has an invalid state parameter")}
+	}
+	if errCode := q.Get("error"); errCode != "" {
+		if desc := q.Get("error_description"); desc != "" {
+			return callbackResult{err: fmt.Errorf("authorization failed: %s (%s)", errCode, desc)}
+		}
+		return callbackResult{err: fmt.Errorf("authorization failed: %s", errCode)}
+	}
+
+	code := q.Get("code")
+	if code == "" {
+		return callbackResult{err: fmt.Errorf("authorization response did not include a code")}
+	}
+	return callbackResult{code: code}
+}
+
+// callbackPage is shown in the browser after the redirect
+const callbackPage = `<!DOCTYPE html>
+<html>
+<head><title>Chrono CLI</title></head>
+<body style="font-family: sans-serif; text-align: center; padding-top: 4em;">
+<h2>%s</h2>
+<p>%s</p>
+</body>
+</html>
+`
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+)
+
+// newAuthorizationServer starts a stand-in authorization server that approves
+// every request and verifies the PKCE proof on token exchange
+func newAuthorizationServer(t *testing.T, denied bool) *httptest.Server {
+	var challenge string
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/authorize":
+			q := r.URL.Query()
+			if q.Get("response_type") != "code" || q.Get("code_challenge_method") != PKCEMethod {
+				t.Errorf("Unexpected authorization request: %s", r.URL.RawQuery)
+			}
+			if !strings.HasPrefix(q.Get("redirect_uri"), "http://127.0.0.1:") {
+				t.Errorf("Expected loopback redirect URI, got %s", q.Get("redirect_uri"))
+			}
+			challenge = q.Get("code_challenge")
+
+			redirect, _ := url.Parse(q.Get("redirect_uri"))
+			params := url.Values{"state": {q.Get("state")}}
+			if denied {
+				params.Set("error", "access_denied")
+			} else {
+				params.Set("code", "test-auth-code")
+			}
+			redirect.RawQuery = params.Encode()
+			http.Redirect(w, r, redirect.String(), http.StatusFound)
+
+		case "/auth/token":
+			var req api.AuthorizationCodeRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.GrantType != "authorization_code" || req.Code != "test-auth-code" {
+				t.Errorf("Unexpected token request: %+v", req)
+			}
+			if ChallengeS256(req.CodeVerifier) != challenge {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(api.LoginResponse{
+				AccessToken:  "test-access-token",
+				RefreshToken: "test-refresh-token",
+				ExpiresIn:    3600,
+				User:         api.User{Email: "test@example.com"},
+			})
+
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+// followRedirects simulates a browser by following the authorization redirects
+func followRedirects(u string) error {
+	resp, err := http.Get(u)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func TestLoopbackFlow_EndToEnd(t *testing.T) {
+	server := newAuthorizationServer(t, false)
+	defer server.Close()
+
+	client := api.NewClient(server.URL)
+	flow := &LoopbackFlow{
+		AuthorizeURL: client.AuthorizeURL(),
+		OpenBrowser: func(u string) error {
+			go followRedirects(u)
+			return nil
+		},
+		Timeout: 5 * time.Second,
+	}
+
+	code, err := flow.Authorize(context.Background())
+	if err != nil {
+		t.Fatalf("Authorize() failed: %v", err)
+	}
+
+	if code.Code != "test-auth-code" {
+		t.Errorf("Code = %v, want test-auth-code", code.Code)
+	}
+
+	resp, err := client.ExchangeAuthorizationCode(context.Background(), &api.AuthorizationCodeRequest{
+		Code:         code.Code,
+		CodeVerifier: code.CodeVerifier,
+		RedirectURI:  code.RedirectURI,
+		ClientID:     code.ClientID,
+	})
+	if err != nil {
+		t.Fatalf("ExchangeAuthorizationCode() failed: %v", err)
+	}
+
+	if resp.AccessToken != "test-access-token" {
+		t.Errorf("AccessToken = %v, want test-access-token", resp.AccessToken)
+	}
+
+	if resp.RefreshToken != "test-refresh-token" {
+		t.Errorf("RefreshToken = %v, want test-refresh-token", resp.RefreshToken)
+	}
+}
+
+func TestLoopbackFlow_AccessDenied(t *testing.T) {
+	server := newAuthorizationServer(t, true)
+	defer server.Close()
+
+	flow := &LoopbackFlow{
+		AuthorizeURL: server.URL + "/auth/authorize",
+		OpenBrowser: func(u string) error {
+			go followRedirects(u)
+			return nil
+		},
+		Timeout: 5 * time.Second,
+	}
+
+	_, err := flow.Authorize(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("Expected access_denied error, got %v", err)
+	}
+}
+
+func TestLoopbackFlow_BrowserUnavailable(t *testing.T) {
+	flow := &LoopbackFlow{
+		AuthorizeURL: "http://127.0.0.1:1/auth/authorize",
+		OpenBrowser: func(u string) error {
+			return errors.New("exec: \"xdg-open\": executable file not found")
+		},
+	}
+
+	_, err := flow.Authorize(context.Background())
+	if !errors.Is(err, ErrBrowserUnavailable) {
+		t.Errorf("Expected ErrBrowserUnavailable, got %v", err)
+	}
+}
+
+func TestParseCallback_InvalidState(t *testing.T) {
+	result := parseCallback(url.Values{"state": {"other"}, "code": {"abc"}}, "expected")
+	if result.err == nil {
+		t.Error("Expected error for mismatched state")
+	}
+}
+
+func TestChallengeS256(t *testing.T) {
+	// Test vector from RFC 7636 Appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	if got := ChallengeS256(verifier); got != want {
+		t.Errorf("ChallengeS256() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+// Package auth provides the OAuth login flows used by Chrono CLI
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// PKCEMethod is the code challenge method sent to the authorization server
+const PKCEMethod = "S256"
+
+// PKCE holds a Proof Key for Code Exchange verifier and its challenge (RFC 7636)
+type PKCE struct {
+	Verifier  string
+	Challenge string
+	Method    string
+}
+
+// NewPKCE generates a random code verifier and its S256 challenge
+func NewPKCE() (*PKCE, error) {
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PKCE{
+		Verifier:  verifier,
+		Challenge: ChallengeS256(verifier),
+		Method:    PKCEMethod,
+	}, nil
+}
+
+// ChallengeS256 derives the S256 code challenge for a verifier
+func ChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomString returns n random bytes encoded as unpadded base64url
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
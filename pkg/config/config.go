@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,13 +19,53 @@ const (
 	// defaultBaseURL is the base API URL for API calls
 	// MCP configs will append /mcp when writing to editor config files
 	defaultBaseURL = "https://platform.aelf.dev/api/v1"
+	// DefaultProfile is the profile used when none has been created or selected
+	DefaultProfile = "default"
 )
 
+// profileNamePattern restricts profile names to simple identifiers
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
 // Config represents the CLI configuration
+//
+// Auth, MCP and Defaults hold the settings of the active profile. They are
+// copied from Profiles on load and written back on save, so commands can use
+// them without knowing which profile is selected.
 type Config struct {
-	Auth   AuthConfig   `yaml:"auth"`
-	MCP    MCPConfig    `yaml:"mcp"`
-	Skills SkillsConfig `yaml:"skills"`
+	CurrentProfile string              `yaml:"current_profile,omitempty"`
+	Profiles       map[string]*Profile `yaml:"profiles,omitempty"`
+	Skills         SkillsConfig        `yaml:"skills"`
+	Credentials    CredentialsConfig   `yaml:"credentials,omitempty"`
+	HTTP           HTTPConfig          `yaml:"http,omitempty"`
+
+	Auth     AuthConfig      `yaml:"-"`
+	MCP      MCPConfig       `yaml:"-"`
+	Defaults ProfileDefaults `yaml:"-"`
+
+	// profile is the name of the profile Auth, MCP and Defaults belong to
+	profile string
+	// env records settings taken from environment variables
+	env envOverrides
+}
+
+// Profile represents the settings for one platform environment
+type Profile struct {
+	Auth     AuthConfig      `yaml:"auth"`
+	MCP      MCPConfig       `yaml:"mcp"`
+	Defaults ProfileDefaults `yaml:"defaults,omitempty"`
+}
+
+// ProfileDefaults represents per-profile defaults for commands
+type ProfileDefaults struct {
+	Editor   string `yaml:"editor,omitempty"`    // AI editor used by mcp-setup
+	Team     string `yaml:"team,omitempty"`      // ID of the active team
+	TeamName string `yaml:"team_name,omitempty"` // name of the active team, for display
+}
+
+// legacyConfig represents the single-environment layout used before profiles
+type legacyConfig struct {
+	Auth AuthConfig `yaml:"auth"`
+	MCP  MCPConfig  `yaml:"mcp"`
 }
 
 // AuthConfig represents authentication configuration
@@ -33,8 +75,19 @@ type AuthConfig struct {
 	TokenExpiry  time.Time `yaml:"token_expiry,omitempty"`
 	UserID       string    `yaml:"user_id,omitempty"`
 	Email        string    `yaml:"email,omitempty"`
+	Method       string    `yaml:"method,omitempty"`        // how the credentials were obtained
+	ClientID     string    `yaml:"client_id,omitempty"`     // service account client ID
+	ClientSecret string    `yaml:"client_secret,omitempty"` // service account client secret
 }
 
+// Authentication methods recorded in AuthConfig.Method
+const (
+	AuthMethodDevice         = "device"
+	AuthMethodBrowser        = "browser"
+	AuthMethodToken          = "token"
+	AuthMethodServiceAccount = "service_account"
+)
+
 // MarshalYAML customizes YAML marshaling for AuthConfig
 func (a AuthConfig) MarshalYAML() (interface{}, error) {
 	// Create a map to manually control what gets serialized
@@ -87,6 +140,30 @@ type MCPConfig struct {
 	APIToken  string `yaml:"api_token"`
 }
 
+// HTTPConfig represents settings of the HTTP client used for API calls
+type HTTPConfig struct {
+	Retry RetryConfig `yaml:"retry,omitempty"`
+
+	// Proxy overrides HTTPS_PROXY/HTTP_PROXY, e.g. http://proxy.corp:3128
+	Proxy string `yaml:"proxy,omitempty"`
+	// CAFile is a PEM bundle trusted in addition to the system roots
+	CAFile string `yaml:"ca_file,omitempty"`
+	// ClientCert and ClientKey are presented to platforms that require mTLS
+	ClientCert string `yaml:"client_cert,omitempty"`
+	ClientKey  string `yaml:"client_key,omitempty"`
+	// InsecureSkipVerify disables TLS certificate verification
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty"`
+}
+
+// RetryConfig tunes how failed API requests are retried
+// Zero values keep the client defaults
+type RetryConfig struct {
+	MaxAttempts    int           `yaml:"max_attempts,omitempty"`    // total attempts; 1 disables retries
+	InitialBackoff time.Duration `yaml:"initial_backoff,omitempty"` // e.g. 500ms
+	MaxBackoff     time.Duration `yaml:"max_backoff,omitempty"`     // e.g. 10s
+	MaxElapsed     time.Duration `yaml:"max_elapsed,omitempty"`     // e.g. 1m
+}
+
 // SkillsConfig represents skills configuration
 type SkillsConfig struct {
 	InstallDir string           `yaml:"install_dir"`
@@ -104,14 +181,42 @@ type InstalledSkill struct {
 
 // IsLoggedIn checks if the user is logged in
 func (c *Config) IsLoggedIn() bool {
+	switch c.Auth.Method {
+	case AuthMethodToken:
+		// API tokens may not carry a known expiry
+		return c.Auth.AccessToken != ""
+	case AuthMethodServiceAccount:
+		// Service accounts can obtain an access token on demand
+		return c.hasClientCredentials() || (c.Auth.AccessToken != "" && !c.Auth.TokenExpiry.IsZero())
+	}
 	return c.Auth.AccessToken != "" && !c.Auth.TokenExpiry.IsZero()
 }
 
 // IsTokenExpired checks if the access token is expired
 func (c *Config) IsTokenExpired() bool {
+	if c.Auth.Method == AuthMethodToken && c.Auth.TokenExpiry.IsZero() {
+		return false
+	}
 	return c.Auth.TokenExpiry.Before(time.Now())
 }
 
+// CanRefresh checks if an expired access token can be renewed, either with
+// the refresh token or the service account client credentials
+func (c *Config) CanRefresh() bool {
+	return c.Auth.RefreshToken != "" || c.hasClientCredentials()
+}
+
+// hasClientCredentials checks if service account client credentials are configured
+func (c *Config) hasClientCredentials() bool {
+	return c.Auth.ClientID != "" && c.Auth.ClientSecret != ""
+}
+
+// HasValidSession checks if the stored credentials can be used,
+// either directly or after refreshing the access token
+func (c *Config) HasValidSession() bool {
+	return c.IsLoggedIn() && (!c.IsTokenExpired() || c.CanRefresh())
+}
+
 // GetConfigPath returns the path to the config file
 func GetConfigPath() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -150,30 +255,171 @@ func EnsureConfigDir() error {
 	return nil
 }
 
-// Load loads the configuration from the config file
+// Load loads the configuration from the config file and selects the current profile
 // If the config file doesn't exist, returns a default config
 func Load() (*Config, error) {
+	return LoadProfile("")
+}
+
+// LoadProfile loads the configuration from the config file and selects the named profile
+// An empty name selects the current profile
+func LoadProfile(name string) (*Config, error) {
 	configPath, err := GetConfigPath()
 	if err != nil {
 		return nil, err
 	}
 
-	// If config file doesn't exist, return default config
+	// If config file doesn't exist, start from the default config
+	var cfg *Config
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return Default(), nil
+		cfg = Default()
+	} else {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+
+		cfg = &Config{}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+
+		// Migrate the single-environment layout into the default profile
+		if len(cfg.Profiles) == 0 {
+			var legacy legacyConfig
+			if err := yaml.Unmarshal(data, &legacy); err != nil {
+				return nil, fmt.Errorf("failed to parse config file: %w", err)
+			}
+			if legacy.MCP.ServerURL == "" {
+				legacy.MCP.ServerURL = defaultBaseURL
+			}
+			cfg.Profiles = map[string]*Profile{
+				DefaultProfile: {Auth: legacy.Auth, MCP: legacy.MCP},
+			}
+		}
 	}
 
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+	if err := cfg.loadCredentials(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = cfg.CurrentProfile
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	if err := cfg.SelectProfile(name); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// ProfileName returns the name of the active profile
+func (c *Config) ProfileName() string {
+	if c.profile != "" {
+		return c.profile
+	}
+	if c.CurrentProfile != "" {
+		return c.CurrentProfile
 	}
+	return DefaultProfile
+}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+// ProfileNames returns the names of all profiles in sorted order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
+}
+
+// HasProfile checks if a profile with the given name exists
+func (c *Config) HasProfile(name string) bool {
+	_, ok := c.Profiles[name]
+	return ok
+}
 
-	return &cfg, nil
+// SelectProfile makes the named profile active for this process
+// Use CurrentProfile to persist the selection
+func (c *Config) SelectProfile(name string) error {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+
+	// Keep unsaved changes of the previously active profile
+	if c.profile != "" && c.profile != name {
+		c.storeProfile()
+	}
+
+	c.profile = name
+	c.Auth = profile.Auth
+	c.MCP = profile.MCP
+	c.Defaults = profile.Defaults
+	return nil
+}
+
+// CreateProfile adds a new profile for the given server URL
+func (c *Config) CreateProfile(name, serverURL string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '_' or '-'", name)
+	}
+	if c.HasProfile(name) {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	if serverURL == "" {
+		serverURL = defaultBaseURL
+	}
+
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]*Profile)
+	}
+	c.Profiles[name] = &Profile{
+		MCP: MCPConfig{ServerURL: serverURL},
+	}
+	return nil
+}
+
+// DeleteProfile removes a profile
+// The active profile cannot be deleted
+func (c *Config) DeleteProfile(name string) error {
+	if !c.HasProfile(name) {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	if name == c.ProfileName() || name == c.CurrentProfile {
+		return fmt.Errorf("cannot delete the active profile %q; switch to another profile first", name)
+	}
+
+	delete(c.Profiles, name)
+	return nil
+}
+
+// storeProfile copies the active settings back into the profile map
+// Settings taken from environment variables are never written to the file
+func (c *Config) storeProfile() {
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]*Profile)
+	}
+
+	auth := c.Auth
+	if c.env.auth != nil {
+		auth = *c.env.auth
+	}
+	mcp := c.MCP
+	if c.env.serverURL != nil {
+		mcp.ServerURL = *c.env.serverURL
+	}
+
+	c.Profiles[c.ProfileName()] = &Profile{
+		Auth:     auth,
+		MCP:      mcp,
+		Defaults: c.Defaults,
+	}
 }
 
 // Save saves the configuration to the config file
@@ -187,7 +433,18 @@ func (c *Config) Save() error {
 		return err
 	}
 
-	data, err := yaml.Marshal(c)
+	c.storeProfile()
+	if c.CurrentProfile == "" {
+		c.CurrentProfile = c.ProfileName()
+	}
+
+	// Move secrets to the credential store, unless they are kept in plaintext
+	out, err := c.saveCredentials()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(out)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -199,9 +456,10 @@ func (c *Config) Save() error {
 	return nil
 }
 
-// Clear clears the authentication data from the config
+// Clear clears the authentication data and the MCP API token from the config
 func (c *Config) Clear() {
 	c.Auth = AuthConfig{}
+	c.MCP.APIToken = ""
 }
 
 // Default returns a default configuration
@@ -212,6 +470,14 @@ func Default() *Config {
 	}
 
 	return &Config{
+		CurrentProfile: DefaultProfile,
+		Profiles: map[string]*Profile{
+			DefaultProfile: {
+				MCP: MCPConfig{
+					ServerURL: defaultBaseURL,
+				},
+			},
+		},
 		MCP: MCPConfig{
 			ServerURL: defaultBaseURL,
 		},
@@ -220,6 +486,7 @@ func Default() *Config {
 			GitHubRepo: "ChronoAIProject/chrono-cli", // Chrono CLI repository (contains skills/)
 			GitHubRef:  "main",                        // Default branch
 		},
+		profile: DefaultProfile,
 	}
 }
 
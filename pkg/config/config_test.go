@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestConfigDefaults(t *testing.T) {
@@ -106,6 +108,49 @@ func TestConfigIsTokenExpired(t *testing.T) {
 	}
 }
 
+func TestConfigHasValidSession(t *testing.T) {
+	tests := []struct {
+		name         string
+		expiry       time.Time
+		refreshToken string
+		expected     bool
+	}{
+		{
+			name:     "token valid",
+			expiry:   time.Now().Add(1 * time.Hour),
+			expected: true,
+		},
+		{
+			name:     "token expired without refresh token",
+			expiry:   time.Now().Add(-1 * time.Hour),
+			expected: false,
+		},
+		{
+			name:         "token expired with refresh token",
+			expiry:       time.Now().Add(-1 * time.Hour),
+			refreshToken: "test-refresh-token",
+			expected:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Auth: AuthConfig{
+					AccessToken:  "test-token",
+					RefreshToken: tt.refreshToken,
+					TokenExpiry:  tt.expiry,
+				},
+			}
+
+			result := cfg.HasValidSession()
+			if result != tt.expected {
+				t.Errorf("HasValidSession() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestConfigSaveAndLoad(t *testing.T) {
 	// Create a temporary directory for testing
 	tmpDir := t.TempDir()
@@ -173,6 +218,7 @@ func TestConfigClear(t *testing.T) {
 		},
 		MCP: MCPConfig{
 			ServerURL: "https://api.example.com",
+			APIToken:  "dp_mcp_token",
 		},
 	}
 
@@ -197,6 +243,10 @@ func TestConfigClear(t *testing.T) {
 		t.Error("Expected Email to be cleared")
 	}
 
+	if cfg.MCP.APIToken != "" {
+		t.Error("Expected MCP APIToken to be cleared")
+	}
+
 	// MCP config should remain
 	if cfg.MCP.ServerURL == "" {
 		t.Error("Expected MCP ServerURL to remain after clear")
@@ -294,3 +344,123 @@ func TestLoadReturnsDefaultWhenNotExists(t *testing.T) {
 		t.Error("Expected default config to be returned")
 	}
 }
+
+func TestLoadMigratesLegacyConfig(t *testing.T) {
+	// Create a temporary directory for testing
+	tmpDir := t.TempDir()
+
+	// Override the home directory for this test
+	oldHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", oldHome)
+
+	os.Setenv("HOME", tmpDir)
+
+	// Write a config file in the single-environment layout
+	legacy := legacyConfig{
+		Auth: AuthConfig{
+			AccessToken: "legacy-token",
+			TokenExpiry: time.Now().Add(1 * time.Hour).Truncate(time.Second),
+			Email:       "legacy@example.com",
+		},
+		MCP: MCPConfig{
+			ServerURL: "https://legacy.example.com",
+		},
+	}
+	data, err := yaml.Marshal(legacy)
+	if err != nil {
+		t.Fatalf("Failed to marshal legacy config: %v", err)
+	}
+	if err := EnsureConfigDir(); err != nil {
+		t.Fatalf("EnsureConfigDir() failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, configDir, configFile), data, 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if cfg.ProfileName() != DefaultProfile {
+		t.Errorf("ProfileName() = %v, want %v", cfg.ProfileName(), DefaultProfile)
+	}
+
+	if cfg.Auth.AccessToken != legacy.Auth.AccessToken {
+		t.Errorf("AccessToken = %v, want %v", cfg.Auth.AccessToken, legacy.Auth.AccessToken)
+	}
+
+	if cfg.MCP.ServerURL != legacy.MCP.ServerURL {
+		t.Errorf("ServerURL = %v, want %v", cfg.MCP.ServerURL, legacy.MCP.ServerURL)
+	}
+}
+
+func TestConfigProfiles(t *testing.T) {
+	// Create a temporary directory for testing
+	tmpDir := t.TempDir()
+
+	// Override the home directory for this test
+	oldHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", oldHome)
+
+	os.Setenv("HOME", tmpDir)
+
+	cfg := Default()
+	cfg.Auth.AccessToken = "prod-token"
+
+	if err := cfg.CreateProfile("staging", "https://staging.example.com"); err != nil {
+		t.Fatalf("CreateProfile() failed: %v", err)
+	}
+
+	if err := cfg.CreateProfile("staging", ""); err == nil {
+		t.Error("Expected error creating duplicate profile")
+	}
+
+	if err := cfg.CreateProfile("bad name", ""); err == nil {
+		t.Error("Expected error creating profile with invalid name")
+	}
+
+	// Switch to staging and log in there
+	if err := cfg.SelectProfile("staging"); err != nil {
+		t.Fatalf("SelectProfile() failed: %v", err)
+	}
+	cfg.CurrentProfile = "staging"
+	cfg.Auth.AccessToken = "staging-token"
+
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	// Current profile is selected by default
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if loaded.ProfileName() != "staging" {
+		t.Errorf("ProfileName() = %v, want staging", loaded.ProfileName())
+	}
+	if loaded.Auth.AccessToken != "staging-token" {
+		t.Errorf("AccessToken = %v, want staging-token", loaded.Auth.AccessToken)
+	}
+	if loaded.MCP.ServerURL != "https://staging.example.com" {
+		t.Errorf("ServerURL = %v, want https://staging.example.com", loaded.MCP.ServerURL)
+	}
+
+	// Other profiles keep their own credentials
+	loaded, err = LoadProfile(DefaultProfile)
+	if err != nil {
+		t.Fatalf("LoadProfile() failed: %v", err)
+	}
+	if loaded.Auth.AccessToken != "prod-token" {
+		t.Errorf("AccessToken = %v, want prod-token", loaded.Auth.AccessToken)
+	}
+
+	if _, err := LoadProfile("missing"); err == nil {
+		t.Error("Expected error loading unknown profile")
+	}
+
+	// The current profile cannot be deleted
+	if err := loaded.DeleteProfile("staging"); err == nil {
+		t.Error("Expected error deleting current profile")
+	}
+}
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Credential store names used in CredentialsConfig.Store
+const (
+	CredentialStorePlaintext     = "plaintext"
+	CredentialStoreFile          = "file"
+	CredentialStoreSecretService = "secret-service"
+)
+
+// EnvCredentialsPassphrase holds the passphrase of the encrypted file store.
+// Without it, the key is derived from a local key file.
+const EnvCredentialsPassphrase = "CHRONO_CREDENTIALS_PASSPHRASE"
+
+const (
+	credentialsFile = "credentials.enc"
+	credentialsKey  = "credentials.key"
+)
+
+// CredentialsConfig selects where secrets are stored
+type CredentialsConfig struct {
+	Store   string `yaml:"store,omitempty"`    // plaintext (default), file or secret-service
+	KeyFile string `yaml:"key_file,omitempty"` // key file of the file store (default: see keyHome)
+}
+
+// Secrets holds the secret fields of a profile
+type Secrets struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	APIToken     string `json:"api_token,omitempty"`
+}
+
+// IsEmpty checks if no secret is set
+func (s Secrets) IsEmpty() bool {
+	return s == Secrets{}
+}
+
+// CredentialStore keeps the secrets of all profiles outside the config file
+//
+// The secrets are read and written as a whole, keyed by profile name.
+type CredentialStore interface {
+	// Name returns the store name used in the config file
+	Name() string
+	// Load returns the stored secrets, or an empty map if there are none
+	Load() (map[string]Secrets, error)
+	// Save replaces the stored secrets
+	Save(secrets map[string]Secrets) error
+	// Delete removes all stored secrets
+	Delete() error
+}
+
+// NewCredentialStore returns the store with the given name. The plaintext
+// store keeps secrets in the config file, so it returns nil.
+func NewCredentialStore(cfg CredentialsConfig) (CredentialStore, error) {
+	switch cfg.Store {
+	case "", CredentialStorePlaintext:
+		return nil, nil
+	case CredentialStoreFile:
+		dir, err := configHome()
+		if err != nil {
+			return nil, err
+		}
+		store := &FileStore{
+			Path:       filepath.Join(dir, credentialsFile),
+			KeyFile:    cfg.KeyFile,
+			Passphrase: os.Getenv(EnvCredentialsPassphrase),
+		}
+		if store.KeyFile == "" {
+			keyDir, err := keyHome()
+			if err != nil {
+				return nil, err
+			}
+			store.KeyFile = filepath.Join(keyDir, credentialsKey)
+			store.LegacyKeyFile = filepath.Join(dir, credentialsKey)
+		}
+		return store, nil
+	case CredentialStoreSecretService:
+		if !SecretServiceAvailable() {
+			return nil, fmt.Errorf("secret service is not available (requires secret-tool and a D-Bus session)")
+		}
+		return &SecretServiceStore{}, nil
+	default:
+		return nil, fmt.Errorf("unknown credential store %q: use plaintext, file or secret-service", cfg.Store)
+	}
+}
+
+// DefaultCredentialStore returns the most secure store available on this machine
+func DefaultCredentialStore() string {
+	if SecretServiceAvailable() {
+		return CredentialStoreSecretService
+	}
+	return CredentialStoreFile
+}
+
+// configHome returns the path to the config directory
+func configHome() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, configDir), nil
+}
+
+// keyHome returns the directory of the default key file of the file store.
+// It is kept out of ~/.chrono, so backups and dotfile sync of the config
+// directory do not carry the key along with the encrypted credentials:
+// $XDG_STATE_HOME/chrono or ~/.local/state/chrono on Linux and other Unix
+// systems, ~/Library/Application Support/chrono on macOS and the
+// non-roaming %LocalAppData%\chrono on Windows.
+func keyHome() (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get application support directory: %w", err)
+		}
+		return filepath.Join(dir, "chrono"), nil
+	case "windows":
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get local application data directory: %w", err)
+		}
+		return filepath.Join(dir, "chrono"), nil
+	}
+
+	if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "chrono"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".local", "state", "chrono"), nil
+}
+
+// profileSecrets extracts the secrets of a profile
+func profileSecrets(p *Profile) Secrets {
+	return Secrets{
+		AccessToken:  p.Auth.AccessToken,
+		RefreshToken: p.Auth.RefreshToken,
+		ClientSecret: p.Auth.ClientSecret,
+		APIToken:     p.MCP.APIToken,
+	}
+}
+
+// setProfileSecrets replaces the secrets of a profile
+func setProfileSecrets(p *Profile, s Secrets) {
+	p.Auth.AccessToken = s.AccessToken
+	p.Auth.RefreshToken = s.RefreshToken
+	p.Auth.ClientSecret = s.ClientSecret
+	p.MCP.APIToken = s.APIToken
+}
+
+// loadCredentials fills the profiles with secrets from the configured store
+func (c *Config) loadCredentials() error {
+	store, err := NewCredentialStore(c.Credentials)
+	if err != nil || store == nil {
+		return err
+	}
+
+	secrets, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load credentials from %s store: %w", store.Name(), err)
+	}
+
+	for name, profile := range c.Profiles {
+		if s, ok := secrets[name]; ok {
+			setProfileSecrets(profile, s)
+		}
+	}
+	return nil
+}
+
+// saveCredentials writes the secrets of all profiles to the configured store and
+// returns a copy of the config without them. With the plaintext store, it returns c.
+func (c *Config) saveCredentials() (*Config, error) {
+	store, err := NewCredentialStore(c.Credentials)
+	if err != nil || store == nil {
+		return c, err
+	}
+
+	secrets := make(map[string]Secrets, len(c.Profiles))
+	stripped := *c
+	stripped.Profiles = make(map[string]*Profile, len(c.Profiles))
+	for name, profile := range c.Profiles {
+		if s := profileSecrets(profile); !s.IsEmpty() {
+			secrets[name] = s
+		}
+		p := *profile
+		setProfileSecrets(&p, Secrets{})
+		stripped.Profiles[name] = &p
+	}
+
+	if err := store.Save(secrets); err != nil {
+		return nil, fmt.Errorf("failed to save credentials to %s store: %w", store.Name(), err)
+	}
+	return &stripped, nil
+}
+
+// MigrateCredentials moves the secrets of all profiles to another store and
+// saves the config. Secrets left in the previous store are deleted.
+func (c *Config) MigrateCredentials(to CredentialsConfig) error {
+	from, err := NewCredentialStore(c.Credentials)
+	if err != nil {
+		return err
+	}
+	if _, err := NewCredentialStore(to); err != nil {
+		return err
+	}
+
+	c.Credentials = to
+	if err := c.Save(); err != nil {
+		return err
+	}
+
+	if from != nil && from.Name() != to.Store {
+		if err := from.Delete(); err != nil {
+			return fmt.Errorf("credentials migrated, but failed to delete them from the %s store: %w", from.Name(), err)
+		}
+	}
+	return nil
+}
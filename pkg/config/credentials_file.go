@@ -0,0 +1,242 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// passphraseIterations is the PBKDF2 cost for user-chosen passphrases
+	passphraseIterations = 600000
+	keyFileSize          = 32
+	keySize              = 32
+	saltSize             = 16
+
+	// kdfPBKDF2 stretches passphrases; kdfHKDF expands random key files,
+	// which need no stretching
+	kdfPBKDF2 = "pbkdf2-sha256"
+	kdfHKDF   = "hkdf-sha256"
+	hkdfInfo  = "chrono credentials"
+)
+
+// ErrWrongPassphrase is returned when the credentials file cannot be decrypted
+var ErrWrongPassphrase = errors.New("cannot decrypt credentials: wrong passphrase or key file")
+
+// FileStore keeps secrets in a file encrypted with AES-256-GCM
+//
+// The key is derived with PBKDF2-SHA256 from Passphrase if set, or else with
+// HKDF-SHA256 from the contents of KeyFile, which is created with random
+// bytes on first use. LegacyKeyFile is the key file of earlier versions,
+// which kept it next to the credentials; it is still read, and removed once
+// the secrets are saved with KeyFile.
+type FileStore struct {
+	Path          string
+	KeyFile       string
+	LegacyKeyFile string
+	Passphrase    string
+}
+
+// encryptedFile is the on-disk format of the credentials file
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations,omitempty"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Name returns the store name
+func (s *FileStore) Name() string {
+	return CredentialStoreFile
+}
+
+// Load decrypts the credentials file
+func (s *FileStore) Load() (map[string]Secrets, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return map[string]Secrets{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+	if file.Version != 1 || (file.KDF != kdfPBKDF2 && file.KDF != kdfHKDF) {
+		return nil, fmt.Errorf("unsupported credentials file format (version %d, kdf %q)", file.Version, file.KDF)
+	}
+
+	passwords, err := s.loadPasswords()
+	if err != nil {
+		return nil, err
+	}
+	for _, password := range passwords {
+		key, err := deriveKey(password, &file)
+		if err != nil {
+			return nil, err
+		}
+		gcm, err := newGCM(key)
+		if err != nil {
+			return nil, err
+		}
+		plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+		if err != nil {
+			continue
+		}
+
+		secrets := map[string]Secrets{}
+		if err := json.Unmarshal(plaintext, &secrets); err != nil {
+			return nil, fmt.Errorf("failed to parse credentials: %w", err)
+		}
+		return secrets, nil
+	}
+	return nil, ErrWrongPassphrase
+}
+
+// Save encrypts the secrets with a fresh salt and nonce and replaces the file
+func (s *FileStore) Save(secrets map[string]Secrets) error {
+	password, err := s.savePassword()
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	file := encryptedFile{Version: 1, KDF: kdfHKDF, Salt: make([]byte, saltSize)}
+	if s.Passphrase != "" {
+		file.KDF, file.Iterations = kdfPBKDF2, passphraseIterations
+	}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := deriveKey(password, &file)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	file.Ciphertext = gcm.Seal(nil, file.Nonce, plaintext, nil)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+	if err := os.WriteFile(s.Path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+
+	// The legacy key file no longer decrypts anything
+	if s.Passphrase == "" && s.LegacyKeyFile != "" && s.LegacyKeyFile != s.KeyFile {
+		if err := os.Remove(s.LegacyKeyFile); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old key file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Delete removes the credentials file. The key file is kept.
+func (s *FileStore) Delete() error {
+	if err := os.Remove(s.Path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove credentials file: %w", err)
+	}
+	return nil
+}
+
+// loadPasswords returns the secrets the key may be derived from: the
+// passphrase, or the contents of the key file and the legacy key file
+func (s *FileStore) loadPasswords() ([][]byte, error) {
+	if s.Passphrase != "" {
+		return [][]byte{[]byte(s.Passphrase)}, nil
+	}
+
+	var passwords [][]byte
+	key, keyErr := os.ReadFile(s.KeyFile)
+	if keyErr == nil {
+		passwords = append(passwords, key)
+	}
+	if s.LegacyKeyFile != "" && s.LegacyKeyFile != s.KeyFile {
+		if legacy, err := os.ReadFile(s.LegacyKeyFile); err == nil {
+			passwords = append(passwords, legacy)
+		}
+	}
+	if len(passwords) == 0 {
+		return nil, fmt.Errorf("no %s set and key file unavailable: %w", EnvCredentialsPassphrase, keyErr)
+	}
+	return passwords, nil
+}
+
+// savePassword returns the secret the key is derived from when saving. A
+// missing key file is generated.
+func (s *FileStore) savePassword() ([]byte, error) {
+	if s.Passphrase != "" {
+		return []byte(s.Passphrase), nil
+	}
+
+	key, err := os.ReadFile(s.KeyFile)
+	if os.IsNotExist(err) {
+		key = make([]byte, keyFileSize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(s.KeyFile), 0700); err != nil {
+			return nil, fmt.Errorf("failed to create key directory: %w", err)
+		}
+		if err := os.WriteFile(s.KeyFile, key, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write key file: %w", err)
+		}
+		return key, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	return key, nil
+}
+
+// deriveKey derives the AES-256 key of a credentials file with its KDF
+func deriveKey(password []byte, file *encryptedFile) ([]byte, error) {
+	if file.KDF == kdfPBKDF2 {
+		return pbkdf2.Key(password, file.Salt, file.Iterations, keySize, sha256.New), nil
+	}
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, password, file.Salt, []byte(hkdfInfo)), key); err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return key, nil
+}
+
+// newGCM returns an AES-GCM cipher for the key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// secretTool is the Secret Service command-line client (libsecret)
+var secretTool = "secret-tool"
+
+// secretAttributes identify the Chrono CLI item in the Secret Service
+var secretAttributes = []string{"service", "chrono-cli", "account", "credentials"}
+
+// SecretServiceStore keeps secrets in the desktop keyring through the
+// freedesktop.org Secret Service API (GNOME Keyring, KWallet), using secret-tool
+type SecretServiceStore struct{}
+
+// SecretServiceAvailable checks if secret-tool and a D-Bus session are available
+func SecretServiceAvailable() bool {
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return false
+	}
+	_, err := exec.LookPath(secretTool)
+	return err == nil
+}
+
+// Name returns the store name
+func (s *SecretServiceStore) Name() string {
+	return CredentialStoreSecretService
+}
+
+// Load reads the secrets from the keyring
+func (s *SecretServiceStore) Load() (map[string]Secrets, error) {
+	cmd := exec.Command(secretTool, append([]string{"lookup"}, secretAttributes...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && stderr.Len() == 0 {
+		// secret-tool exits with status 1 and no message when nothing is stored
+		return map[string]Secrets{}, nil
+	}
+	if err != nil {
+		return nil, secretToolError(err, &stderr)
+	}
+
+	secrets := map[string]Secrets{}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return secrets, nil
+	}
+	if err := json.Unmarshal(out, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+	return secrets, nil
+}
+
+// Save replaces the secrets in the keyring
+func (s *SecretServiceStore) Save(secrets map[string]Secrets) error {
+	data, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	args := append([]string{"store", "--label=Chrono CLI credentials"}, secretAttributes...)
+	cmd := exec.Command(secretTool, args...)
+	cmd.Stdin = bytes.NewReader(data)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return secretToolError(err, &stderr)
+	}
+	return nil
+}
+
+// Delete removes the secrets from the keyring
+func (s *SecretServiceStore) Delete() error {
+	cmd := exec.Command(secretTool, append([]string{"clear"}, secretAttributes...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return secretToolError(err, &stderr)
+	}
+	return nil
+}
+
+// secretToolError adds the output of secret-tool to an error
+func secretToolError(err error, stderr *bytes.Buffer) error {
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("secret-tool failed: %s", msg)
+	}
+	return fmt.Errorf("secret-tool failed: %w", err)
+}
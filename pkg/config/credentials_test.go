@@ -0,0 +1,274 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	tmpDir := t.TempDir()
+	secrets := map[string]Secrets{
+		"default": {AccessToken: "access-123", RefreshToken: "refresh-123"},
+		"staging": {APIToken: "dp_staging"},
+	}
+
+	t.Run("key file", func(t *testing.T) {
+		store := &FileStore{
+			Path:    filepath.Join(tmpDir, "keyfile.enc"),
+			KeyFile: filepath.Join(tmpDir, "credentials.key"),
+		}
+		if err := store.Save(secrets); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+
+		if _, err := os.Stat(store.KeyFile); err != nil {
+			t.Errorf("Expected key file to be created: %v", err)
+		}
+
+		data, _ := os.ReadFile(store.Path)
+		if strings.Contains(string(data), "access-123") {
+			t.Error("Credentials file contains plaintext secret")
+		}
+		if !strings.Contains(string(data), `"kdf": "hkdf-sha256"`) {
+			t.Errorf("Credentials file does not use HKDF for the key file:\n%s", data)
+		}
+
+		loaded, err := store.Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		if loaded["default"] != secrets["default"] || loaded["staging"] != secrets["staging"] {
+			t.Errorf("Load() = %v, want %v", loaded, secrets)
+		}
+	})
+
+	t.Run("passphrase", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "passphrase.enc")
+		store := &FileStore{Path: path, Passphrase: "correct horse"}
+		if err := store.Save(secrets); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+
+		loaded, err := store.Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		if loaded["default"].AccessToken != "access-123" {
+			t.Errorf("AccessToken = %v, want access-123", loaded["default"].AccessToken)
+		}
+
+		wrong := &FileStore{Path: path, Passphrase: "battery staple"}
+		if _, err := wrong.Load(); !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("Load() with wrong passphrase error = %v, want ErrWrongPassphrase", err)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		store := &FileStore{Path: filepath.Join(tmpDir, "missing.enc"), Passphrase: "x"}
+		loaded, err := store.Load()
+		if err != nil || len(loaded) != 0 {
+			t.Errorf("Load() = %v, %v, want empty map", loaded, err)
+		}
+	})
+}
+
+func TestFileStore_LegacyKeyFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &FileStore{
+		Path:          filepath.Join(tmpDir, ".chrono", "credentials.enc"),
+		KeyFile:       filepath.Join(tmpDir, "state", "credentials.key"),
+		LegacyKeyFile: filepath.Join(tmpDir, ".chrono", "credentials.key"),
+	}
+
+	// A file written by earlier versions: the key file next to the
+	// credentials and a single PBKDF2 iteration
+	legacyKey := []byte("0123456789abcdef0123456789abcdef")
+	if err := os.MkdirAll(filepath.Dir(store.Path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(store.LegacyKeyFile, legacyKey, 0600); err != nil {
+		t.Fatal(err)
+	}
+	file := encryptedFile{Version: 1, KDF: kdfPBKDF2, Iterations: 1, Salt: []byte("0123456789abcdef")}
+	key, err := deriveKey(legacyKey, &file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	file.Ciphertext = gcm.Seal(nil, file.Nonce, []byte(`{"default":{"access_token":"access-123"}}`), nil)
+	data, _ := json.Marshal(file)
+	if err := os.WriteFile(store.Path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() with legacy key file failed: %v", err)
+	}
+	if loaded["default"].AccessToken != "access-123" {
+		t.Fatalf("AccessToken = %v, want access-123", loaded["default"].AccessToken)
+	}
+
+	// Saving moves to the new key file and removes the legacy one
+	if err := store.Save(loaded); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if _, err := os.Stat(store.KeyFile); err != nil {
+		t.Errorf("Expected new key file to be created: %v", err)
+	}
+	if _, err := os.Stat(store.LegacyKeyFile); !os.IsNotExist(err) {
+		t.Error("Expected legacy key file to be removed")
+	}
+	loaded, err = store.Load()
+	if err != nil || loaded["default"].AccessToken != "access-123" {
+		t.Errorf("Load() after Save() = %v, %v", loaded, err)
+	}
+}
+
+func TestConfigSaveWithFileStore(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv(EnvCredentialsPassphrase, "")
+
+	cfg := Default()
+	cfg.Credentials.Store = CredentialStoreFile
+	cfg.Auth = AuthConfig{
+		AccessToken:  "secret-access-token",
+		RefreshToken: "secret-refresh-token",
+		TokenExpiry:  time.Now().Add(time.Hour),
+		Email:        "test@example.com",
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	if cfg.Auth.AccessToken != "secret-access-token" {
+		t.Error("Save() must not clear secrets of the in-memory config")
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, configDir, configFile))
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	if strings.Contains(string(data), "secret-") {
+		t.Errorf("Config file contains secrets:\n%s", data)
+	}
+	if !strings.Contains(string(data), "test@example.com") {
+		t.Error("Config file is missing non-secret fields")
+	}
+
+	// The key must not sit next to the credentials it protects
+	if _, err := os.Stat(filepath.Join(tmpDir, configDir, credentialsKey)); !os.IsNotExist(err) {
+		t.Error("Expected no key file in the config directory")
+	}
+	if runtime.GOOS == "linux" {
+		if _, err := os.Stat(filepath.Join(tmpDir, ".local", "state", "chrono", credentialsKey)); err != nil {
+			t.Errorf("Expected key file in the state directory: %v", err)
+		}
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if loaded.Auth.AccessToken != "secret-access-token" || loaded.Auth.RefreshToken != "secret-refresh-token" {
+		t.Errorf("Load() did not restore secrets: %+v", loaded.Auth)
+	}
+}
+
+func TestMigrateCredentials(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv(EnvCredentialsPassphrase, "")
+
+	cfg := Default()
+	cfg.Auth = AuthConfig{AccessToken: "secret-access-token", Method: AuthMethodToken}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	if err := cfg.MigrateCredentials(CredentialsConfig{Store: CredentialStoreFile}); err != nil {
+		t.Fatalf("MigrateCredentials() to file failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(tmpDir, configDir, configFile))
+	if strings.Contains(string(data), "secret-access-token") {
+		t.Error("Config file still contains the secret after migration")
+	}
+
+	// And back to plaintext, which removes the encrypted file
+	if err := cfg.MigrateCredentials(CredentialsConfig{Store: CredentialStorePlaintext}); err != nil {
+		t.Fatalf("MigrateCredentials() to plaintext failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, configDir, credentialsFile)); !os.IsNotExist(err) {
+		t.Error("Expected credentials file to be removed")
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if loaded.Auth.AccessToken != "secret-access-token" {
+		t.Errorf("AccessToken = %v, want secret-access-token", loaded.Auth.AccessToken)
+	}
+}
+
+func TestSecretServiceStore(t *testing.T) {
+	// Stand-in for secret-tool that keeps the secret in a file
+	tmpDir := t.TempDir()
+	storage := filepath.Join(tmpDir, "secret")
+	script := filepath.Join(tmpDir, "secret-tool")
+	err := os.WriteFile(script, []byte(`#!/bin/sh
+case "$1" in
+  store) cat > "`+storage+`" ;;
+  lookup) [ -f "`+storage+`" ] || exit 1; cat "`+storage+`" ;;
+  clear) rm -f "`+storage+`" ;;
+esac
+`), 0755)
+	if err != nil {
+		t.Fatalf("Failed to write stand-in secret-tool: %v", err)
+	}
+
+	old := secretTool
+	secretTool = script
+	defer func() { secretTool = old }()
+
+	store := &SecretServiceStore{}
+
+	loaded, err := store.Load()
+	if err != nil || len(loaded) != 0 {
+		t.Fatalf("Load() before Save() = %v, %v, want empty map", loaded, err)
+	}
+
+	if err := store.Save(map[string]Secrets{"default": {AccessToken: "access-123"}}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	loaded, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if loaded["default"].AccessToken != "access-123" {
+		t.Errorf("AccessToken = %v, want access-123", loaded["default"].AccessToken)
+	}
+
+	if err := store.Delete(); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, err := os.Stat(storage); !os.IsNotExist(err) {
+		t.Error("Expected secret to be removed")
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// Environment variables for non-interactive use (e.g. CI jobs)
+const (
+	EnvToken        = "CHRONO_TOKEN"
+	EnvAPIURL       = "CHRONO_API_URL"
+	EnvClientID     = "CHRONO_CLIENT_ID"
+	EnvClientSecret = "CHRONO_CLIENT_SECRET"
+)
+
+// Environment variables for testing against recorded API interactions
+const (
+	// EnvRecord saves every API interaction to the given directory
+	EnvRecord = "CHRONO_RECORD"
+	// EnvReplay serves API responses from a directory recorded with CHRONO_RECORD
+	EnvReplay = "CHRONO_REPLAY"
+)
+
+// envOverrides keeps the file values of settings replaced from the environment,
+// so saving the config never persists them
+type envOverrides struct {
+	auth      *AuthConfig
+	serverURL *string
+	source    string
+}
+
+// ApplyEnvironment overrides the active profile with settings from environment variables
+//
+// CHRONO_API_URL replaces the server URL. CHRONO_TOKEN replaces the stored
+// credentials with an API token; otherwise CHRONO_CLIENT_ID and
+// CHRONO_CLIENT_SECRET select service account authentication.
+func (c *Config) ApplyEnvironment() {
+	if url := os.Getenv(EnvAPIURL); url != "" {
+		if c.env.serverURL == nil {
+			original := c.MCP.ServerURL
+			c.env.serverURL = &original
+		}
+		c.MCP.ServerURL = url
+	}
+
+	var auth *AuthConfig
+	switch {
+	case os.Getenv(EnvToken) != "":
+		auth = &AuthConfig{
+			AccessToken: os.Getenv(EnvToken),
+			Method:      AuthMethodToken,
+		}
+		c.env.source = fmt.Sprintf("environment variable %s", EnvToken)
+	case os.Getenv(EnvClientID) != "" && os.Getenv(EnvClientSecret) != "":
+		auth = &AuthConfig{
+			ClientID:     os.Getenv(EnvClientID),
+			ClientSecret: os.Getenv(EnvClientSecret),
+			Method:       AuthMethodServiceAccount,
+		}
+		c.env.source = fmt.Sprintf("environment variables %s/%s", EnvClientID, EnvClientSecret)
+	}
+
+	if auth != nil {
+		if c.env.auth == nil {
+			original := c.Auth
+			c.env.auth = &original
+		}
+		c.Auth = *auth
+	}
+}
+
+// HasEnvironmentCredentials checks if the credentials come from environment variables
+func (c *Config) HasEnvironmentCredentials() bool {
+	return c.env.auth != nil
+}
+
+// CredentialSource describes where the active credentials come from
+func (c *Config) CredentialSource() string {
+	if c.env.source != "" {
+		return c.env.source
+	}
+
+	var method string
+	switch c.Auth.Method {
+	case AuthMethodBrowser:
+		method = "browser login"
+	case AuthMethodToken:
+		method = "API token"
+	case AuthMethodServiceAccount:
+		method = "service account"
+	default:
+		method = "device login"
+	}
+	return fmt.Sprintf("%s (profile %q)", method, c.ProfileName())
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApplyEnvironmentToken(t *testing.T) {
+	// Create a temporary directory for testing
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv(EnvToken, "dp_ci_token")
+	t.Setenv(EnvAPIURL, "https://ci.example.com/api/v1")
+
+	cfg := Default()
+	cfg.Auth = AuthConfig{
+		AccessToken: "stored-token",
+		TokenExpiry: time.Now().Add(1 * time.Hour),
+		Email:       "dev@example.com",
+	}
+	cfg.ApplyEnvironment()
+
+	if cfg.Auth.AccessToken != "dp_ci_token" {
+		t.Errorf("AccessToken = %v, want dp_ci_token", cfg.Auth.AccessToken)
+	}
+
+	if cfg.MCP.ServerURL != "https://ci.example.com/api/v1" {
+		t.Errorf("ServerURL = %v, want https://ci.example.com/api/v1", cfg.MCP.ServerURL)
+	}
+
+	if !cfg.HasValidSession() {
+		t.Error("Expected environment token to be a valid session")
+	}
+
+	if !strings.Contains(cfg.CredentialSource(), EnvToken) {
+		t.Errorf("CredentialSource() = %v, want mention of %s", cfg.CredentialSource(), EnvToken)
+	}
+
+	// Environment values must not be written to the config file
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	os.Unsetenv(EnvToken)
+	os.Unsetenv(EnvAPIURL)
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if loaded.Auth.AccessToken != "stored-token" {
+		t.Errorf("AccessToken = %v, want stored-token", loaded.Auth.AccessToken)
+	}
+
+	if loaded.MCP.ServerURL != defaultBaseURL {
+		t.Errorf("ServerURL = %v, want %v", loaded.MCP.ServerURL, defaultBaseURL)
+	}
+}
+
+func TestApplyEnvironmentServiceAccount(t *testing.T) {
+	t.Setenv(EnvToken, "")
+	t.Setenv(EnvClientID, "sa-ci")
+	t.Setenv(EnvClientSecret, "sa-secret")
+
+	cfg := Default()
+	cfg.ApplyEnvironment()
+
+	if cfg.Auth.Method != AuthMethodServiceAccount {
+		t.Errorf("Method = %v, want %v", cfg.Auth.Method, AuthMethodServiceAccount)
+	}
+
+	// Service accounts obtain an access token on demand
+	if !cfg.HasValidSession() {
+		t.Error("Expected service account credentials to be a valid session")
+	}
+
+	if !cfg.HasEnvironmentCredentials() {
+		t.Error("Expected HasEnvironmentCredentials() to be true")
+	}
+}
+
+func TestAPITokenWithoutExpiry(t *testing.T) {
+	cfg := &Config{
+		Auth: AuthConfig{
+			AccessToken: "dp_token",
+			Method:      AuthMethodToken,
+		},
+	}
+
+	if !cfg.IsLoggedIn() {
+		t.Error("Expected API token to be logged in")
+	}
+
+	if cfg.IsTokenExpired() {
+		t.Error("Expected API token without expiry to not be expired")
+	}
+}
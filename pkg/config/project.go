@@ -0,0 +1,81 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// projectLinkFile is the file in a repository's .chrono directory that
+// records the platform project the repository belongs to
+const projectLinkFile = "project.yaml"
+
+// ErrNotLinked is returned when a repository is not linked to a project
+var ErrNotLinked = errors.New("not linked to a project. Run 'chrono link' first")
+
+// ProjectLink records the platform project a repository deploys to. It is
+// written by 'chrono link' to .chrono/project.yaml at the repository root.
+type ProjectLink struct {
+	ProjectID   string    `yaml:"project_id"`
+	ProjectName string    `yaml:"project_name,omitempty"`
+	TeamID      string    `yaml:"team_id,omitempty"`
+	Server      string    `yaml:"server,omitempty"` // API URL the project lives on
+	LinkedAt    time.Time `yaml:"linked_at,omitempty"`
+}
+
+// FindProjectLink looks for .chrono/project.yaml in dir and its parents,
+// stopping at the home directory, whose .chrono holds the CLI configuration.
+// It returns the link and the directory containing .chrono, or ErrNotLinked.
+func FindProjectLink(dir string) (*ProjectLink, string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	home, _ := os.UserHomeDir()
+
+	for dir != home {
+		path := filepath.Join(dir, configDir, projectLinkFile)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			var link ProjectLink
+			if err := yaml.Unmarshal(data, &link); err != nil {
+				return nil, "", fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			if link.ProjectID == "" {
+				return nil, "", fmt.Errorf("%s has no project_id", path)
+			}
+			return &link, dir, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, "", fmt.Errorf("failed to read project link: %w", err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return nil, "", ErrNotLinked
+}
+
+// SaveProjectLink writes the link to .chrono/project.yaml in dir
+func SaveProjectLink(dir string, link *ProjectLink) error {
+	chronoDir := filepath.Join(dir, configDir)
+	if err := os.MkdirAll(chronoDir, 0755); err != nil {
+		return fmt.Errorf("failed to create .chrono directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("failed to marshal project link: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(chronoDir, projectLinkFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write project link: %w", err)
+	}
+	return nil
+}
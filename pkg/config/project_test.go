@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProjectLink(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "backend", "cmd")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := FindProjectLink(nested); !errors.Is(err, ErrNotLinked) {
+		t.Fatalf("FindProjectLink() before linking: error = %v, want ErrNotLinked", err)
+	}
+
+	link := &ProjectLink{
+		ProjectID:   "proj-1",
+		ProjectName: "shop",
+		TeamID:      "team-1",
+		Server:      "https://platform.example.com/api/v1",
+		LinkedAt:    time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if err := SaveProjectLink(root, link); err != nil {
+		t.Fatalf("SaveProjectLink() failed: %v", err)
+	}
+
+	got, dir, err := FindProjectLink(nested)
+	if err != nil {
+		t.Fatalf("FindProjectLink() failed: %v", err)
+	}
+	if dir != root {
+		t.Errorf("dir = %q, want %q", dir, root)
+	}
+	if *got != *link {
+		t.Errorf("link = %+v, want %+v", got, link)
+	}
+}
+
+func TestFindProjectLink_Invalid(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".chrono"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".chrono", "project.yaml"), []byte("project_name: shop\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := FindProjectLink(root); err == nil || errors.Is(err, ErrNotLinked) {
+		t.Errorf("FindProjectLink() error = %v, want an invalid link error", err)
+	}
+}
+
+func TestFindProjectLink_StopsAtHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := SaveProjectLink(home, &ProjectLink{ProjectID: "proj-1"}); err != nil {
+		t.Fatal(err)
+	}
+	repo := filepath.Join(home, "src", "shop")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := FindProjectLink(repo); !errors.Is(err, ErrNotLinked) {
+		t.Errorf("FindProjectLink() error = %v, want ErrNotLinked", err)
+	}
+}
@@ -0,0 +1,86 @@
+package gitinfo
+
+import "fmt"
+
+// Severity tells whether a problem blocks a deployment
+type Severity string
+
+const (
+	// SeverityError means the platform cannot build what is checked out
+	SeverityError Severity = "error"
+	// SeverityWarning means the deployment differs from the local checkout
+	SeverityWarning Severity = "warning"
+)
+
+// Problem is a reason the repository may not deploy as expected
+type Problem struct {
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Remedy   string   `json:"remedy"` // command or action that resolves it
+}
+
+// Check returns the problems that keep the platform from deploying the
+// checked-out commit. The platform builds the branch as pushed to origin,
+// so local commits and changes must be pushed first.
+func Check(s *Status) []Problem {
+	var problems []Problem
+	add := func(severity Severity, remedy, format string, args ...any) {
+		problems = append(problems, Problem{Severity: severity, Message: fmt.Sprintf(format, args...), Remedy: remedy})
+	}
+
+	switch {
+	case s.RemoteURL == "":
+		add(SeverityError, "git remote add origin <repository URL>", "the repository has no %s remote", RemoteName)
+	case s.Remote == nil:
+		add(SeverityError, "git remote set-url origin https://<host>/<owner>/<repo>.git",
+			"the %s remote %q does not name a hosted repository", RemoteName, s.RemoteURL)
+	}
+
+	if s.Head == "" {
+		add(SeverityError, "git commit", "the repository has no commits")
+		return problems
+	}
+	if s.Detached {
+		add(SeverityError, "git switch <branch>", "HEAD is detached at %s; the platform deploys branches", s.ShortHead())
+		return problems
+	}
+
+	// Branches other than the checked-out one are pushed and updated by name
+	push, pull := "git push", "git pull"
+	if !s.CheckedOut {
+		push, pull = "git push "+RemoteName+" "+s.Branch, "git fetch "+RemoteName+" "+s.Branch+":"+s.Branch
+	}
+	switch {
+	case s.RemoteBranch == "":
+		add(SeverityError, "git push -u "+RemoteName+" "+s.Branch, "branch %s has not been pushed to %s", s.Branch, RemoteName)
+	case s.Ahead > 0:
+		add(SeverityError, push, "branch %s has %s not pushed to %s", s.Branch, plural(s.Ahead, "commit", "commits"), RemoteName)
+	}
+	if s.Behind > 0 {
+		add(SeverityWarning, pull,
+			"%s is %s behind %s; the platform deploys %s", s.Branch, plural(s.Behind, "commit", "commits"), s.RemoteBranch, s.RemoteBranch)
+	}
+	if s.Dirty() {
+		add(SeverityWarning, "commit and push them, or 'git stash' them",
+			"%s in the working tree will not be deployed", plural(len(s.Changes), "uncommitted change", "uncommitted changes"))
+	}
+	return problems
+}
+
+// HasErrors reports whether any problem blocks a deployment
+func HasErrors(problems []Problem) bool {
+	for _, p := range problems {
+		if p.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// plural formats a count with the singular or plural form of a noun
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, pluralForm)
+}
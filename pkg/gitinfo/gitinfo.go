@@ -0,0 +1,193 @@
+// Package gitinfo inspects the Git repository being deployed: its origin
+// remote, branch, working tree and unpushed commits
+package gitinfo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ErrNotRepository is returned when a directory is not inside a Git work tree
+var ErrNotRepository = errors.New("not a git repository")
+
+// ErrGitNotFound is returned when the git executable is not installed
+var ErrGitNotFound = errors.New("git is not installed or not in PATH")
+
+// ErrUnknownBranch is returned when a branch exists neither locally nor on
+// the remote as of the last fetch
+var ErrUnknownBranch = errors.New("branch not found locally or on origin")
+
+// RemoteName is the remote the platform builds from
+const RemoteName = "origin"
+
+// Status describes a repository. Comparisons with the remote use the
+// remote-tracking branches as of the last fetch.
+type Status struct {
+	Root      string  `json:"root"`
+	RemoteURL string  `json:"remote_url,omitempty"`
+	Remote    *Remote `json:"remote,omitempty"` // nil without a parsable origin remote
+	Branch    string  `json:"branch,omitempty"` // empty when HEAD is detached
+	Detached  bool    `json:"detached"`
+	Head      string  `json:"head,omitempty"` // empty before the first commit
+
+	// CheckedOut tells whether Branch is the checked-out branch. The working
+	// tree is only inspected when it is.
+	CheckedOut bool `json:"checked_out"`
+	// Changes are the uncommitted changes in `git status --porcelain` format
+	Changes []string `json:"changes,omitempty"`
+
+	// RemoteBranch is the branch on origin, e.g. "origin/main", or empty
+	// when the branch has not been pushed
+	RemoteBranch string `json:"remote_branch,omitempty"`
+	// Ahead is the number of local commits not on the remote branch
+	Ahead int `json:"ahead"`
+	// Behind is the number of remote commits not merged locally
+	Behind int `json:"behind"`
+}
+
+// Dirty reports whether the working tree has uncommitted changes
+func (s *Status) Dirty() bool {
+	return len(s.Changes) > 0
+}
+
+// Repository returns the repository as owner/repo, or an empty string
+func (s *Status) Repository() string {
+	if s.Remote == nil {
+		return ""
+	}
+	return s.Remote.FullName()
+}
+
+// ShortHead returns the abbreviated head commit
+func (s *Status) ShortHead() string {
+	if len(s.Head) > 7 {
+		return s.Head[:7]
+	}
+	return s.Head
+}
+
+// Root returns the top-level directory of the repository containing dir
+func Root(dir string) (string, error) {
+	return git(dir, "rev-parse", "--show-toplevel")
+}
+
+// Inspect returns the status of the repository containing dir and its
+// checked-out branch
+func Inspect(dir string) (*Status, error) {
+	return inspect(dir, "")
+}
+
+// InspectBranch returns the status of a branch of the repository containing
+// dir. For the checked-out branch it is the same as Inspect. For other
+// branches the working tree is not inspected, and Head is the local branch,
+// or the remote branch when there is no local one.
+func InspectBranch(dir, branch string) (*Status, error) {
+	s, err := inspect(dir, branch)
+	if err != nil {
+		return nil, err
+	}
+	if !s.CheckedOut && s.Head == "" {
+		return nil, fmt.Errorf("%s: %w", branch, ErrUnknownBranch)
+	}
+	return s, nil
+}
+
+// inspect returns the status of branch, or of the checked-out branch if
+// branch is empty
+func inspect(dir, branch string) (*Status, error) {
+	root, err := Root(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &Status{Root: root}
+
+	s.RemoteURL, _ = git(root, "config", "--get", "remote."+RemoteName+".url")
+	if s.RemoteURL != "" {
+		s.Remote, _ = ParseRemote(s.RemoteURL)
+	}
+
+	// symbolic-ref fails when HEAD points to a commit instead of a branch
+	current, _ := git(root, "symbolic-ref", "--short", "-q", "HEAD")
+	ref := "HEAD"
+	if branch == "" || branch == current {
+		s.CheckedOut = true
+		s.Branch, s.Detached = current, current == ""
+
+		// Fails before the first commit
+		s.Head, _ = git(root, "rev-parse", "--verify", "-q", "HEAD")
+
+		changes, err := git(root, "status", "--porcelain")
+		if err != nil {
+			return nil, err
+		}
+		if changes != "" {
+			s.Changes = strings.Split(changes, "\n")
+		}
+	} else {
+		s.Branch = branch
+		ref = "refs/heads/" + branch
+		s.Head, _ = git(root, "rev-parse", "--verify", "-q", ref)
+	}
+
+	if s.Branch == "" {
+		return s, nil
+	}
+	remoteBranch := RemoteName + "/" + s.Branch
+	remoteHead, err := git(root, "rev-parse", "--verify", "-q", "refs/remotes/"+remoteBranch)
+	if err != nil {
+		if s.Head == "" {
+			return s, nil
+		}
+		// Not pushed yet: count the commits that are on no remote branch
+		count, err := git(root, "rev-list", "--count", ref, "--not", "--remotes="+RemoteName)
+		if err != nil {
+			return nil, err
+		}
+		s.Ahead, _ = strconv.Atoi(count)
+		return s, nil
+	}
+
+	s.RemoteBranch = remoteBranch
+	if s.Head == "" {
+		if !s.CheckedOut {
+			// Only on the remote, so there is nothing to push
+			s.Head = remoteHead
+		}
+		return s, nil
+	}
+	counts, err := git(root, "rev-list", "--left-right", "--count", remoteBranch+"..."+ref)
+	if err != nil {
+		return nil, err
+	}
+	if behind, ahead, ok := strings.Cut(counts, "\t"); ok {
+		s.Behind, _ = strconv.Atoi(behind)
+		s.Ahead, _ = strconv.Atoi(ahead)
+	}
+	return s, nil
+}
+
+// git runs a git command in dir and returns its trimmed output
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return "", ErrGitNotFound
+	}
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "not a git repository") {
+			return "", ErrNotRepository
+		}
+		if msg == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
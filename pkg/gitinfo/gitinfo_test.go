@@ -0,0 +1,270 @@
+package gitinfo
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// remoteURL is the origin URL of test repositories. Git rewrites it to a
+// local bare repository, so pushes and fetches work offline.
+const remoteURL = "https://github.com/acme/shop.git"
+
+// testRepo is a repository with an origin remote backed by a bare repository
+type testRepo struct {
+	t   *testing.T
+	dir string
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// Isolate the tests from the user's Git configuration
+	base := t.TempDir()
+	t.Setenv("HOME", base)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(base, "gitconfig"))
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	bare := filepath.Join(base, "remote", "shop.git")
+	r := &testRepo{t: t, dir: filepath.Join(base, "shop")}
+	r.git(base, "init", "-q", "--bare", bare)
+	r.git(base, "config", "--global", "url."+bare+".insteadOf", remoteURL)
+	r.git(base, "init", "-q", "-b", "main", r.dir)
+	r.git(r.dir, "remote", "add", "origin", remoteURL)
+	return r
+}
+
+// git runs a git command in dir and returns its output
+func (r *testRepo) git(dir string, args ...string) string {
+	r.t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commit writes a file and commits it
+func (r *testRepo) commit(name, content string) {
+	r.t.Helper()
+	if err := os.WriteFile(filepath.Join(r.dir, name), []byte(content), 0644); err != nil {
+		r.t.Fatal(err)
+	}
+	r.git(r.dir, "add", name)
+	r.git(r.dir, "commit", "-q", "-m", "update "+name)
+}
+
+func (r *testRepo) inspect() *Status {
+	r.t.Helper()
+	s, err := Inspect(r.dir)
+	if err != nil {
+		r.t.Fatalf("Inspect() failed: %v", err)
+	}
+	return s
+}
+
+func TestInspect(t *testing.T) {
+	r := newTestRepo(t)
+
+	// Before the first commit
+	s := r.inspect()
+	if s.Head != "" || s.Branch != "main" || s.Detached {
+		t.Errorf("empty repository: Head = %q, Branch = %q, Detached = %v", s.Head, s.Branch, s.Detached)
+	}
+	if s.Repository() != "acme/shop" || s.Remote.Host != "github.com" {
+		t.Errorf("Remote = %+v, want github.com acme/shop", s.Remote)
+	}
+
+	// Committed but not pushed
+	r.commit("README.md", "shop\n")
+	r.commit("main.go", "package main\n")
+	s = r.inspect()
+	if len(s.Head) != 40 || s.RemoteBranch != "" || s.Ahead != 2 {
+		t.Errorf("unpushed branch: Head = %q, RemoteBranch = %q, Ahead = %d", s.Head, s.RemoteBranch, s.Ahead)
+	}
+
+	// Pushed, then one more local commit and an uncommitted change
+	r.git(r.dir, "push", "-q", "-u", "origin", "main")
+	r.commit("go.mod", "module shop\n")
+	if err := os.WriteFile(filepath.Join(r.dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s = r.inspect()
+	if s.RemoteBranch != "origin/main" || s.Ahead != 1 || s.Behind != 0 {
+		t.Errorf("ahead: RemoteBranch = %q, Ahead = %d, Behind = %d", s.RemoteBranch, s.Ahead, s.Behind)
+	}
+	if !s.Dirty() || len(s.Changes) != 1 || s.Changes[0] != " M main.go" {
+		t.Errorf("Changes = %q, want [\" M main.go\"]", s.Changes)
+	}
+
+	// Behind after the remote moved on
+	r.git(r.dir, "checkout", "-q", "main.go")
+	r.git(r.dir, "push", "-q")
+	r.git(r.dir, "reset", "-q", "--hard", "HEAD~1")
+	s = r.inspect()
+	if s.Ahead != 0 || s.Behind != 1 || s.Dirty() {
+		t.Errorf("behind: Ahead = %d, Behind = %d, Changes = %q", s.Ahead, s.Behind, s.Changes)
+	}
+
+	// Detached HEAD
+	r.git(r.dir, "checkout", "-q", "--detach", "HEAD~1")
+	s = r.inspect()
+	if !s.Detached || s.Branch != "" {
+		t.Errorf("detached: Detached = %v, Branch = %q", s.Detached, s.Branch)
+	}
+}
+
+func TestInspectBranch(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("README.md", "shop\n")
+	r.git(r.dir, "push", "-q", "-u", "origin", "main")
+
+	// A local branch with a commit that was never pushed, and uncommitted
+	// changes on the checked-out branch
+	r.git(r.dir, "switch", "-q", "-c", "feature")
+	r.commit("feature.go", "package main\n")
+	r.git(r.dir, "switch", "-q", "main")
+	if err := os.WriteFile(filepath.Join(r.dir, "notes.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := InspectBranch(r.dir, "feature")
+	if err != nil {
+		t.Fatalf("InspectBranch() failed: %v", err)
+	}
+	if s.CheckedOut || s.Branch != "feature" || s.RemoteBranch != "" || s.Ahead != 1 || s.Dirty() {
+		t.Errorf("unpushed branch: %+v", s)
+	}
+
+	// Pushed, then one more local commit
+	r.git(r.dir, "push", "-q", "origin", "feature")
+	r.git(r.dir, "switch", "-q", "feature")
+	r.commit("go.mod", "module shop\n")
+	r.git(r.dir, "switch", "-q", "main")
+	s, err = InspectBranch(r.dir, "feature")
+	if err != nil {
+		t.Fatalf("InspectBranch() failed: %v", err)
+	}
+	if s.RemoteBranch != "origin/feature" || s.Ahead != 1 || s.Behind != 0 {
+		t.Errorf("ahead: RemoteBranch = %q, Ahead = %d, Behind = %d", s.RemoteBranch, s.Ahead, s.Behind)
+	}
+
+	// Only on the remote
+	r.git(r.dir, "branch", "-q", "-D", "feature")
+	s, err = InspectBranch(r.dir, "feature")
+	if err != nil {
+		t.Fatalf("InspectBranch() failed: %v", err)
+	}
+	if s.Head != r.git(r.dir, "rev-parse", "origin/feature") || s.Ahead != 0 {
+		t.Errorf("remote only: Head = %q, Ahead = %d", s.Head, s.Ahead)
+	}
+
+	// The checked-out branch is inspected as by Inspect
+	s, err = InspectBranch(r.dir, "main")
+	if err != nil {
+		t.Fatalf("InspectBranch() failed: %v", err)
+	}
+	if !s.CheckedOut || !s.Dirty() {
+		t.Errorf("checked-out branch: CheckedOut = %v, Changes = %q", s.CheckedOut, s.Changes)
+	}
+
+	if _, err := InspectBranch(r.dir, "nope"); !errors.Is(err, ErrUnknownBranch) {
+		t.Errorf("InspectBranch() error = %v, want ErrUnknownBranch", err)
+	}
+}
+
+func TestInspect_Subdirectory(t *testing.T) {
+	r := newTestRepo(t)
+	sub := filepath.Join(r.dir, "backend", "cmd")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Inspect(sub)
+	if err != nil {
+		t.Fatalf("Inspect() failed: %v", err)
+	}
+	root, _ := filepath.EvalSymlinks(r.dir)
+	if got, _ := filepath.EvalSymlinks(s.Root); got != root {
+		t.Errorf("Root = %q, want %q", s.Root, r.dir)
+	}
+
+	top, err := Root(sub)
+	if err != nil {
+		t.Fatalf("Root() failed: %v", err)
+	}
+	if got, _ := filepath.EvalSymlinks(top); got != root {
+		t.Errorf("Root() = %q, want %q", top, r.dir)
+	}
+}
+
+func TestInspect_NotRepository(t *testing.T) {
+	newTestRepo(t) // isolates the Git configuration
+	t.Setenv("GIT_CEILING_DIRECTORIES", os.TempDir())
+
+	if _, err := Inspect(t.TempDir()); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("Inspect() error = %v, want ErrNotRepository", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	ready := Status{
+		RemoteURL:    remoteURL,
+		Remote:       &Remote{Host: "github.com", Owner: "acme", Repo: "shop"},
+		Branch:       "main",
+		Head:         "0123456789abcdef0123456789abcdef01234567",
+		CheckedOut:   true,
+		RemoteBranch: "origin/main",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *Status)
+		want   []Severity
+	}{
+		{name: "ready", modify: func(s *Status) {}},
+		{name: "no remote", modify: func(s *Status) { s.RemoteURL, s.Remote = "", nil }, want: []Severity{SeverityError}},
+		{name: "local remote", modify: func(s *Status) { s.RemoteURL, s.Remote = "/srv/git/shop.git", nil }, want: []Severity{SeverityError}},
+		{name: "no commits", modify: func(s *Status) { s.Head = "" }, want: []Severity{SeverityError}},
+		{name: "detached", modify: func(s *Status) { s.Branch, s.Detached = "", true }, want: []Severity{SeverityError}},
+		{name: "not pushed", modify: func(s *Status) { s.RemoteBranch, s.Ahead = "", 2 }, want: []Severity{SeverityError}},
+		{name: "unpushed commits", modify: func(s *Status) { s.Ahead = 1 }, want: []Severity{SeverityError}},
+		{name: "behind", modify: func(s *Status) { s.Behind = 3 }, want: []Severity{SeverityWarning}},
+		{name: "dirty", modify: func(s *Status) { s.Changes = []string{"?? notes.txt"} }, want: []Severity{SeverityWarning}},
+		{name: "other branch not pushed", modify: func(s *Status) { s.Branch, s.CheckedOut, s.RemoteBranch, s.Ahead = "feature", false, "", 1 }, want: []Severity{SeverityError}},
+		{name: "diverged and dirty", modify: func(s *Status) { s.Ahead, s.Behind, s.Changes = 1, 1, []string{" M a"} }, want: []Severity{SeverityError, SeverityWarning, SeverityWarning}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ready
+			tt.modify(&s)
+			problems := Check(&s)
+
+			var got []Severity
+			for _, p := range problems {
+				if p.Message == "" || p.Remedy == "" {
+					t.Errorf("problem %+v has no message or remedy", p)
+				}
+				got = append(got, p.Severity)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %+v, want severities %v", problems, tt.want)
+			}
+			if HasErrors(problems) != (len(tt.want) > 0 && tt.want[0] == SeverityError) {
+				t.Errorf("HasErrors() = %v", HasErrors(problems))
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package gitinfo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Remote is a parsed Git remote URL
+type Remote struct {
+	Host  string `json:"host"`  // e.g. github.com or git.example.com, without port
+	Owner string `json:"owner"` // user, organization or group path, e.g. "acme" or "acme/web"
+	Repo  string `json:"repo"`  // repository name without the .git suffix
+}
+
+// FullName returns the repository as owner/repo
+func (r *Remote) FullName() string {
+	return r.Owner + "/" + r.Repo
+}
+
+// ParseRemote parses HTTPS, SSH and scp-like remote URLs of GitHub, GitLab
+// and self-hosted servers, e.g. https://github.com/acme/shop.git,
+// git@github.com:acme/shop.git and ssh://git@git.example.com:2222/acme/web/shop
+func ParseRemote(remote string) (*Remote, error) {
+	remote = strings.TrimSpace(remote)
+
+	var host, path string
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote URL %q: %w", remote, err)
+		}
+		switch u.Scheme {
+		case "https", "http", "ssh", "git", "git+ssh", "ssh+git":
+		default:
+			return nil, fmt.Errorf("unsupported remote URL %q: %s remotes are not hosted repositories", remote, u.Scheme)
+		}
+		host, path = u.Hostname(), u.Path
+	} else {
+		// scp-like syntax: [user@]host:owner/repo
+		at := strings.LastIndex(remote, "@")
+		hostPart, p, ok := strings.Cut(remote[at+1:], ":")
+		if !ok || strings.Contains(hostPart, "/") {
+			return nil, fmt.Errorf("unsupported remote URL %q", remote)
+		}
+		host, path = hostPart, p
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	i := strings.LastIndex(path, "/")
+	if host == "" || i <= 0 || i == len(path)-1 {
+		return nil, fmt.Errorf("remote URL %q does not name an owner and repository", remote)
+	}
+	return &Remote{Host: strings.ToLower(host), Owner: path[:i], Repo: path[i+1:]}, nil
+}
@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxAppNameLength is the longest DNS label
+const maxAppNameLength = 63
+
+// dnsLabel matches valid application names (RFC 1123 labels)
+var dnsLabel = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// invalidAppNameChars matches runs of characters not allowed in an app name
+var invalidAppNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ValidateAppName checks that name can be used as an application name: a DNS
+// label of lowercase letters, digits and '-', at most 63 characters
+func ValidateAppName(name string) error {
+	if !dnsLabel.MatchString(name) {
+		return fmt.Errorf("invalid app name %q: use lowercase letters, digits and '-', start and end with a letter or digit, at most %d characters", name, maxAppNameLength)
+	}
+	return nil
+}
+
+// AppName derives the application name of a branch of a repository as
+// {repo}-{branch}, e.g. "shop-feature-login" for acme/shop on feature/login.
+// Characters that are not allowed in a DNS label are replaced by '-' and
+// the name is shortened to 63 characters.
+func AppName(repository, branch string) (string, error) {
+	repo := repository
+	if i := strings.LastIndex(repo, "/"); i >= 0 {
+		repo = repo[i+1:]
+	}
+
+	name := sanitize(repo)
+	if b := sanitize(branch); b != "" {
+		name = strings.Trim(name+"-"+b, "-")
+	}
+	if len(name) > maxAppNameLength {
+		name = strings.TrimRight(name[:maxAppNameLength], "-")
+	}
+
+	if err := ValidateAppName(name); err != nil {
+		return "", fmt.Errorf("cannot derive an app name from %q and branch %q; set one with --app-name", repository, branch)
+	}
+	return name, nil
+}
+
+// sanitize lowercases s and replaces runs of invalid characters by '-'
+func sanitize(s string) string {
+	s = invalidAppNameChars.ReplaceAllString(strings.ToLower(s), "-")
+	return strings.Trim(s, "-")
+}
@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAppName(t *testing.T) {
+	tests := []struct {
+		repository string
+		branch     string
+		want       string
+	}{
+		{repository: "acme/shop", branch: "main", want: "shop-main"},
+		{repository: "acme/Shop_API", branch: "feature/Login", want: "shop-api-feature-login"},
+		{repository: "group/sub/shop", branch: "release-1.2", want: "shop-release-1-2"},
+		{repository: "acme/shop", branch: "--", want: "shop"},
+		{repository: "acme/" + strings.Repeat("a", 60), branch: "main", want: strings.Repeat("a", 60) + "-ma"},
+		{repository: "acme/" + strings.Repeat("a", 62), branch: "main", want: strings.Repeat("a", 62)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repository+"@"+tt.branch, func(t *testing.T) {
+			got, err := AppName(tt.repository, tt.branch)
+			if err != nil {
+				t.Fatalf("AppName() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AppName() = %q, want %q", got, tt.want)
+			}
+			if err := ValidateAppName(got); err != nil {
+				t.Errorf("derived name is invalid: %v", err)
+			}
+		})
+	}
+
+	if _, err := AppName("acme/__", "..."); err == nil {
+		t.Error("AppName() without usable characters succeeded")
+	}
+}
+
+func TestValidateAppName(t *testing.T) {
+	valid := []string{"shop", "shop-main", "a", "1shop", strings.Repeat("a", 63)}
+	for _, name := range valid {
+		if err := ValidateAppName(name); err != nil {
+			t.Errorf("ValidateAppName(%q) = %v, want nil", name, err)
+		}
+	}
+
+	invalid := []string{"", "Shop", "shop_main", "-shop", "shop-", "shop.main", strings.Repeat("a", 64)}
+	for _, name := range invalid {
+		if err := ValidateAppName(name); err == nil {
+			t.Errorf("ValidateAppName(%q) succeeded, want error", name)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Package pipeline builds platform pipeline requests from the project
+// metadata written by 'chrono detect --save'
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/ChronoAIProject/chrono-cli/pkg/detector"
+	"gopkg.in/yaml.v3"
+)
+
+// MetadataPath is the path of the metadata file relative to the repository root
+var MetadataPath = filepath.Join(".chrono", "metadata.yaml")
+
+// ErrNoMetadata is returned when a repository has no saved metadata
+var ErrNoMetadata = errors.New("no project metadata found. Run 'chrono detect --save' first")
+
+// placeholderValue is the value the detector records for variables whose
+// value it does not copy from .env files
+const placeholderValue = "[VALUE]"
+
+// LoadMetadata reads .chrono/metadata.yaml from the repository root dir
+func LoadMetadata(dir string) (*detector.Metadata, error) {
+	path := filepath.Join(dir, MetadataPath)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoMetadata
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	var meta detector.Metadata
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &meta, nil
+}
+
+// IsSecret reports whether a variable name looks sensitive, e.g. API_KEY or
+// JWT_SECRET. Such variables are sent as secrets, never as plain env vars.
+func IsSecret(name string) bool {
+	return api.IsSecretName(name)
+}
+
+// Options are the values of a pipeline that are not part of the metadata
+type Options struct {
+	ProjectID   string
+	Repository  string // owner/repo
+	Branch      string
+	Environment string // empty for the platform default
+	AppName     string // overrides the derived {repo}-{branch} name
+
+	// FrontendEnv, BackendEnv and Secrets add variables or set the values
+	// of detected ones
+	FrontendEnv map[string]string
+	BackendEnv  map[string]string
+	Secrets     map[string]string
+}
+
+// Plan is a pipeline request built from metadata
+type Plan struct {
+	Request *api.CreatePipelineRequest
+	// Unset lists detected variables without a value. They are left out of
+	// the request; set them with Options or 'chrono pipelines update'.
+	Unset []string
+}
+
+// FromMetadata turns detected metadata into a create pipeline request.
+// Frontend variables are sent as frontend env vars; backend variables are
+// sent as env vars, or as secrets when their name looks sensitive.
+func FromMetadata(meta *detector.Metadata, opts Options) (*Plan, error) {
+	if opts.ProjectID == "" {
+		return nil, errors.New("project ID is required")
+	}
+	if opts.Repository == "" || opts.Branch == "" {
+		return nil, errors.New("repository and branch are required")
+	}
+
+	appType, err := appType(meta.Project.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	appName := opts.AppName
+	if appName == "" {
+		if appName, err = AppName(opts.Repository, opts.Branch); err != nil {
+			return nil, err
+		}
+	} else if err := ValidateAppName(appName); err != nil {
+		return nil, err
+	}
+
+	req := &api.CreatePipelineRequest{
+		ProjectID:   opts.ProjectID,
+		Name:        appName,
+		AppName:     appName,
+		Repository:  opts.Repository,
+		Branch:      opts.Branch,
+		Environment: opts.Environment,
+		AppType:     appType,
+		Middleware:  middleware(meta.Middleware),
+	}
+	plan := &Plan{Request: req}
+
+	frontend := map[string]string{}
+	if stack := meta.TechStack.Frontend; stack != nil {
+		for name, value := range stack.EnvVars {
+			frontend[name] = value
+		}
+	}
+	backend := map[string]string{}
+	secrets := map[string]string{}
+	if stack := meta.TechStack.Backend; stack != nil {
+		req.BackendPort = stack.Port
+		for name, value := range stack.EnvVars {
+			if IsSecret(name) {
+				secrets[name] = value
+			} else {
+				backend[name] = value
+			}
+		}
+	}
+
+	// Explicit values win; a secret given explicitly is never sent as a
+	// plain variable
+	for name, value := range opts.FrontendEnv {
+		frontend[name] = value
+	}
+	for name, value := range opts.BackendEnv {
+		backend[name] = value
+	}
+	for name, value := range opts.Secrets {
+		delete(backend, name)
+		secrets[name] = value
+	}
+
+	req.FrontendEnvVars = plan.resolve(frontend)
+	req.BackendEnvVars = plan.resolve(backend)
+	req.Secrets = plan.resolve(secrets)
+	sort.Strings(plan.Unset)
+	return plan, nil
+}
+
+// resolve returns the variables that have a value, recording the others
+// in p.Unset
+func (p *Plan) resolve(vars map[string]string) map[string]string {
+	resolved := map[string]string{}
+	for name, value := range vars {
+		if value == "" || value == placeholderValue {
+			p.Unset = append(p.Unset, name)
+			continue
+		}
+		resolved[name] = value
+	}
+	if len(resolved) == 0 {
+		return nil
+	}
+	return resolved
+}
+
+// appType maps a detected project type to a pipeline application type
+func appType(t detector.ProjectType) (string, error) {
+	switch t {
+	case detector.ProjectTypeFrontend:
+		return api.AppTypeFrontend, nil
+	case detector.ProjectTypeBackend:
+		return api.AppTypeBackend, nil
+	case detector.ProjectTypeFullstack:
+		return api.AppTypeFullstack, nil
+	}
+	return "", fmt.Errorf("unsupported project type %q. Run 'chrono detect' to check the project structure", t)
+}
+
+// middleware returns the platform names of the detected middleware
+func middleware(m detector.Middleware) []string {
+	var names []string
+	if m.MongoDB {
+		names = append(names, "mongodb")
+	}
+	if m.Redis {
+		names = append(names, "redis")
+	}
+	if m.Postgres {
+		names = append(names, "postgresql")
+	}
+	if m.MySQL {
+		names = append(names, "mysql")
+	}
+	return names
+}
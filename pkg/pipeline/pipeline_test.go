@@ -0,0 +1,162 @@
+package pipeline
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/ChronoAIProject/chrono-cli/pkg/detector"
+)
+
+const fullstackMetadata = `project:
+  name: shop
+  type: fullstack
+  detected_at: "2026-01-01T12:00:00Z"
+tech_stack:
+  frontend:
+    framework: nextjs
+    language: typescript
+    port: 3000
+    has_dockerfile: false
+    env_vars:
+      NEXT_PUBLIC_API_URL: https://shop-api.example.com
+      NEXT_PUBLIC_SENTRY_DSN: '[VALUE]'
+  backend:
+    framework: express
+    language: javascript
+    port: 8080
+    has_dockerfile: true
+    dockerfile_path: backend/Dockerfile
+    env_vars:
+      LOG_LEVEL: info
+      NODE_ENV: production
+      GITHUB_TOKEN: '[VALUE]'
+      JWT_AUTH_ISSUER: shop
+middleware:
+  mongodb: true
+  redis: true
+  postgres: false
+  mysql: false
+`
+
+func writeMetadata(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".chrono"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, MetadataPath), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestFromMetadata(t *testing.T) {
+	meta, err := LoadMetadata(writeMetadata(t, fullstackMetadata))
+	if err != nil {
+		t.Fatalf("LoadMetadata() failed: %v", err)
+	}
+
+	plan, err := FromMetadata(meta, Options{
+		ProjectID:  "proj-1",
+		Repository: "acme/shop",
+		Branch:     "feature/login",
+		BackendEnv: map[string]string{"LOG_LEVEL": "debug"},
+		Secrets:    map[string]string{"GITHUB_TOKEN": "ghp_x", "NODE_ENV": "staging"},
+	})
+	if err != nil {
+		t.Fatalf("FromMetadata() failed: %v", err)
+	}
+
+	want := &api.CreatePipelineRequest{
+		ProjectID:       "proj-1",
+		Name:            "shop-feature-login",
+		AppName:         "shop-feature-login",
+		Repository:      "acme/shop",
+		Branch:          "feature/login",
+		AppType:         api.AppTypeFullstack,
+		BackendPort:     8080,
+		FrontendEnvVars: map[string]string{"NEXT_PUBLIC_API_URL": "https://shop-api.example.com"},
+		BackendEnvVars:  map[string]string{"LOG_LEVEL": "debug"},
+		Secrets:         map[string]string{"GITHUB_TOKEN": "ghp_x", "JWT_AUTH_ISSUER": "shop", "NODE_ENV": "staging"},
+		Middleware:      []string{"mongodb", "redis"},
+	}
+	if !reflect.DeepEqual(plan.Request, want) {
+		t.Errorf("Request = %+v, want %+v", plan.Request, want)
+	}
+	if !reflect.DeepEqual(plan.Unset, []string{"NEXT_PUBLIC_SENTRY_DSN"}) {
+		t.Errorf("Unset = %v, want [NEXT_PUBLIC_SENTRY_DSN]", plan.Unset)
+	}
+}
+
+func TestFromMetadata_Frontend(t *testing.T) {
+	meta := &detector.Metadata{
+		Project:   detector.ProjectMetadata{Name: "site", Type: detector.ProjectTypeFrontend},
+		TechStack: detector.TechStackMap{Frontend: &detector.TechStack{Framework: "vite", Port: 5173}},
+	}
+
+	plan, err := FromMetadata(meta, Options{ProjectID: "proj-1", Repository: "acme/site", Branch: "main", AppName: "marketing"})
+	if err != nil {
+		t.Fatalf("FromMetadata() failed: %v", err)
+	}
+	req := plan.Request
+	if req.AppType != api.AppTypeFrontend || req.AppName != "marketing" || req.Name != "marketing" {
+		t.Errorf("Request = %+v, want frontend app named marketing", req)
+	}
+	if req.BackendPort != 0 || req.FrontendEnvVars != nil || req.Middleware != nil {
+		t.Errorf("Request = %+v, want no port, env vars or middleware", req)
+	}
+}
+
+func TestFromMetadata_Invalid(t *testing.T) {
+	frontend := &detector.Metadata{Project: detector.ProjectMetadata{Type: detector.ProjectTypeFrontend}}
+	unknown := &detector.Metadata{Project: detector.ProjectMetadata{Type: detector.ProjectTypeUnknown}}
+	opts := Options{ProjectID: "proj-1", Repository: "acme/shop", Branch: "main"}
+
+	tests := []struct {
+		name string
+		meta *detector.Metadata
+		opts Options
+	}{
+		{name: "unknown project type", meta: unknown, opts: opts},
+		{name: "missing project", meta: frontend, opts: Options{Repository: "acme/shop", Branch: "main"}},
+		{name: "missing branch", meta: frontend, opts: Options{ProjectID: "proj-1", Repository: "acme/shop"}},
+		{name: "invalid app name", meta: frontend, opts: Options{ProjectID: "proj-1", Repository: "acme/shop", Branch: "main", AppName: "Shop_Main"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromMetadata(tt.meta, tt.opts); err == nil {
+				t.Error("FromMetadata() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestLoadMetadata_Missing(t *testing.T) {
+	if _, err := LoadMetadata(t.TempDir()); !errors.Is(err, ErrNoMetadata) {
+		t.Errorf("LoadMetadata() error = %v, want ErrNoMetadata", err)
+	}
+}
+
+func TestIsSecret(t *testing.T) {
+	for name, want := range map[string]bool{
+		"OPENAI_API_KEY":   true,
+		"jwt_secret":       true,
+		"GITHUB_TOKEN":     true,
+		"DB_PASSWORD":      true,
+		"AUTH_URL":         true,
+		"PRIVATE_PEM":      true,
+		"GCP_CREDENTIALS":  true,
+		"PORT":             false,
+		"LOG_LEVEL":        false,
+		"NEXT_PUBLIC_HOST": false,
+	} {
+		if got := IsSecret(name); got != want {
+			t.Errorf("IsSecret(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
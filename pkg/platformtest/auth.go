@@ -0,0 +1,456 @@
+package platformtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+)
+
+// deviceCodeTTL is how long a device code can be approved
+const deviceCodeTTL = 10 * time.Minute
+
+// deviceRequest is a pending device authorization
+type deviceRequest struct {
+	userCode  string
+	expiresAt time.Time
+	approved  bool
+	denied    bool
+	userID    string
+}
+
+// session is an issued access token
+type session struct {
+	userID    string
+	expiresAt time.Time
+}
+
+// apiToken is a stored API token
+type apiToken struct {
+	token  string
+	userID string
+	info   api.APITokenResponse
+}
+
+// AddServiceAccount registers client credentials for the client_credentials grant.
+// Service accounts act as the default user.
+func (s *Server) AddServiceAccount(clientID, clientSecret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.serviceAccounts[clientID] = clientSecret
+}
+
+// ApproveDevice approves the pending device flow request with the user code
+func (s *Server) ApproveDevice(userCode string) error {
+	return s.decideDevice(userCode, true)
+}
+
+// DenyDevice denies the pending device flow request with the user code
+func (s *Server) DenyDevice(userCode string) error {
+	return s.decideDevice(userCode, false)
+}
+
+// decideDevice approves or denies a device flow request
+func (s *Server) decideDevice(userCode string, approve bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	userCode = strings.ToUpper(strings.TrimSpace(userCode))
+	for _, d := range s.devices {
+		if d.userCode == userCode && !d.approved && !d.denied {
+			d.approved = approve
+			d.denied = !approve
+			d.userID = DefaultUserID
+			return nil
+		}
+	}
+	return fmt.Errorf("no pending device request with code %s", userCode)
+}
+
+// AccessToken issues a valid access token for the default user, so tests
+// can skip the login flow
+func (s *Server) AccessToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, _ := s.issueSessionLocked(DefaultUserID)
+	return token
+}
+
+// issueSessionLocked creates an access token and a refresh token for the user.
+// The caller must hold the lock.
+func (s *Server) issueSessionLocked(userID string) (string, string) {
+	now := s.Now()
+	user := s.users[userID]
+	claims := map[string]interface{}{
+		"iss":          "platformtest",
+		"sub":          userID,
+		"aud":          "chrono-cli",
+		"email":        user.Email,
+		"iat":          now.Unix(),
+		"exp":          now.Add(s.AccessTokenTTL).Unix(),
+		"jti":          randomString(8),
+		"realm_access": map[string][]string{"roles": {user.Role}},
+	}
+	header, _ := json.Marshal(map[string]string{"alg": "none", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	accessToken := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload) + ".platformtest"
+
+	refreshToken := "rt_" + randomString(16)
+	s.sessions[accessToken] = &session{userID: userID, expiresAt: now.Add(s.AccessTokenTTL)}
+	s.refreshTokens[refreshToken] = userID
+	return accessToken, refreshToken
+}
+
+// loginResponseLocked issues a session for the user. The caller must hold the lock.
+func (s *Server) loginResponseLocked(userID string) api.LoginResponse {
+	accessToken, refreshToken := s.issueSessionLocked(userID)
+	return api.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(s.AccessTokenTTL.Seconds()),
+		User:         *s.users[userID],
+	}
+}
+
+// authenticated rejects requests without a valid access or API token
+func (s *Server) authenticated(next func(w http.ResponseWriter, r *http.Request, userID string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "missing bearer token")
+			return
+		}
+
+		s.mu.Lock()
+		userID := ""
+		if sess, ok := s.sessions[token]; ok && s.Now().Before(sess.expiresAt) {
+			userID = sess.userID
+		} else if t, ok := s.apiTokens[token]; ok && s.Now().Before(t.info.ExpiresAt) {
+			userID = t.userID
+			now := s.Now()
+			t.info.LastUsedAt = &now
+		}
+		s.mu.Unlock()
+
+		if userID == "" {
+			writeError(w, http.StatusUnauthorized, "invalid_token", "the access token is invalid or expired")
+			return
+		}
+		next(w, r, userID)
+	}
+}
+
+func (s *Server) handleDeviceStart(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	deviceCode := "dc_" + randomString(16)
+	userCode := strings.ToUpper(randomString(2) + "-" + randomString(2))
+	s.devices[deviceCode] = &deviceRequest{userCode: userCode, expiresAt: s.Now().Add(deviceCodeTTL)}
+	s.mu.Unlock()
+
+	verificationURI := externalURL(r) + "/activate"
+	writeJSON(w, http.StatusOK, api.DeviceFlowStartResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + userCode,
+		ExpiresIn:               int(deviceCodeTTL.Seconds()),
+		Interval:                1,
+	})
+}
+
+func (s *Server) handleDevicePoll(w http.ResponseWriter, r *http.Request) {
+	var req api.DeviceFlowPollRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.devices[req.DeviceCode]
+	switch {
+	case !ok:
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "unknown device code")
+	case s.Now().After(d.expiresAt):
+		delete(s.devices, req.DeviceCode)
+		writeOAuthError(w, http.StatusBadRequest, "expired_token", "the device code has expired")
+	case d.denied:
+		delete(s.devices, req.DeviceCode)
+		writeOAuthError(w, http.StatusBadRequest, "access_denied", "the user denied the request")
+	case !d.approved && !s.AutoApprove:
+		writeOAuthError(w, http.StatusBadRequest, "authorization_pending", "waiting for the user to approve")
+	default:
+		delete(s.devices, req.DeviceCode)
+		writeJSON(w, http.StatusOK, s.loginResponseLocked(DefaultUserID))
+	}
+}
+
+// handleActivate serves the page a user visits to approve a device
+func (s *Server) handleActivate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	userCode := r.URL.Query().Get("user_code")
+	if userCode == "" {
+		fmt.Fprint(w, `<!doctype html><title>Activate device</title>
+<h1>Activate device</h1>
+<form><label>Code <input name="user_code" autofocus></label> <button>Approve</button></form>`)
+		return
+	}
+
+	if err := s.ApproveDevice(userCode); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "<!doctype html><title>Activate device</title><h1>%s</h1>", html.EscapeString(err.Error()))
+		return
+	}
+	fmt.Fprintf(w, "<!doctype html><title>Device approved</title><h1>Device %s approved</h1><p>You can return to the terminal.</p>",
+		html.EscapeString(strings.ToUpper(userCode)))
+}
+
+// handleToken implements the client_credentials grant. The authorization
+// code grant used by browser login is not supported.
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	var req api.ClientCredentialsRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.GrantType != "client_credentials" {
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "platformtest supports the client_credentials grant only")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secret, ok := s.serviceAccounts[req.ClientID]
+	if !ok || secret != req.ClientSecret {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "unknown client or wrong secret")
+		return
+	}
+	resp := s.loginResponseLocked(DefaultUserID)
+	resp.RefreshToken = ""
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	var req api.RefreshTokenRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	userID, ok := s.refreshTokens[req.RefreshToken]
+	if !ok {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_grant", "the refresh token is invalid or revoked")
+		return
+	}
+	// Refresh tokens are rotated on every use
+	delete(s.refreshTokens, req.RefreshToken)
+	writeJSON(w, http.StatusOK, s.loginResponseLocked(userID))
+}
+
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	var req api.LogoutRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.refreshTokens, req.RefreshToken)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.users[userID])
+}
+
+func (s *Server) handleListTeams(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	teams := []*api.Team{}
+	for i := range s.users[userID].Teams {
+		teams = append(teams, &s.users[userID].Teams[i])
+	}
+	writeJSON(w, http.StatusOK, api.TeamListResponse{Teams: teams, Total: len(teams)})
+}
+
+func (s *Server) handleGetTeam(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, team := range s.users[userID].Teams {
+		if team.ID == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, team)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "not_found", "team not found")
+}
+
+func (s *Server) handleCreateToken(w http.ResponseWriter, r *http.Request, userID string) {
+	var req api.CreateAPITokenRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "name is required")
+		return
+	}
+	if req.Scope == "" {
+		req.Scope = "personal"
+	}
+	if req.ExpiresIn <= 0 {
+		req.ExpiresIn = int((90 * 24 * time.Hour).Seconds())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.Now()
+	token := "dp_" + randomString(20)
+	t := &apiToken{
+		token:  token,
+		userID: userID,
+		info: api.APITokenResponse{
+			ID:          s.newIDLocked("tok"),
+			Name:        req.Name,
+			TokenPrefix: token[:10] + "...",
+			Scope:       req.Scope,
+			TeamID:      req.TeamID,
+			Role:        s.users[userID].Role,
+			Teams:       []string{},
+			ExpiresAt:   now.Add(time.Duration(req.ExpiresIn) * time.Second),
+			CreatedAt:   now,
+		},
+	}
+	if req.TeamID != "" {
+		t.info.Teams = []string{req.TeamID}
+	}
+	s.apiTokens[token] = t
+
+	writeJSON(w, http.StatusCreated, api.CreateAPITokenResponse{
+		ID:          t.info.ID,
+		Name:        t.info.Name,
+		Token:       token,
+		TokenPrefix: t.info.TokenPrefix,
+		Scope:       t.info.Scope,
+		TeamID:      t.info.TeamID,
+		Role:        t.info.Role,
+		Teams:       t.info.Teams,
+		ExpiresAt:   t.info.ExpiresAt,
+		CreatedAt:   t.info.CreatedAt,
+	})
+}
+
+func (s *Server) handleListTokens(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens := []*api.APITokenResponse{}
+	for _, t := range s.apiTokens {
+		if t.userID == userID {
+			info := t.info
+			tokens = append(tokens, &info)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return idLess(tokens[i].ID, tokens[j].ID) })
+	page, next, ok := paginate(s, w, r, tokens)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, api.APITokenListResponse{Tokens: page, Total: len(tokens), NextCursor: next})
+}
+
+func (s *Server) handleGetToken(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t := s.findTokenLocked(userID, r.PathValue("id")); t != nil {
+		writeJSON(w, http.StatusOK, t.info)
+		return
+	}
+	writeError(w, http.StatusNotFound, "not_found", "token not found")
+}
+
+func (s *Server) handleRevokeToken(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t := s.findTokenLocked(userID, r.PathValue("id")); t != nil {
+		delete(s.apiTokens, t.token)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeError(w, http.StatusNotFound, "not_found", "token not found")
+}
+
+// findTokenLocked returns the user's API token with the ID. The caller must hold the lock.
+func (s *Server) findTokenLocked(userID, id string) *apiToken {
+	for _, t := range s.apiTokens {
+		if t.userID == userID && t.info.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleMCPInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, api.MCPInfoResponse{
+		Name:                  "developer-platform",
+		Version:               s.Version,
+		Instructions:          "In-memory Developer Platform (platformtest)",
+		MinCLIVersion:         s.MinCLIVersion,
+		RecommendedCLIVersion: s.RecommendedCLIVersion,
+	})
+}
+
+func (s *Server) handleListSkills(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	skills := []api.Skill{}
+	for name, content := range s.Skills {
+		skills = append(skills, api.Skill{
+			Name:        name,
+			Description: skillDescription(content),
+			URL:         externalURL(r) + APIPrefix + "/skills/" + name,
+		})
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].Name < skills[j].Name })
+	writeJSON(w, http.StatusOK, api.SkillsListResponse{Skills: skills})
+}
+
+func (s *Server) handleGetSkill(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSuffix(r.PathValue("name"), "/SKILL.md")
+
+	s.mu.Lock()
+	content, ok := s.Skills[name]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "skill not found")
+		return
+	}
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	fmt.Fprint(w, content)
+}
+
+// skillDescription returns the description from a skill's front matter
+func skillDescription(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if desc, ok := strings.CutPrefix(line, "description:"); ok {
+			return strings.TrimSpace(desc)
+		}
+	}
+	return ""
+}
+
+// externalURL returns the scheme and host the request was sent to
+func externalURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
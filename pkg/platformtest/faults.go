@@ -0,0 +1,104 @@
+package platformtest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault makes matching requests fail or slow down
+type Fault struct {
+	// Method matches the request method; empty matches any method
+	Method string
+	// Path matches requests whose path, relative to the API prefix, starts
+	// with it (e.g. "/runs"); empty matches every path
+	Path string
+	// Status is the error status returned; zero only adds Delay
+	Status int
+	// Delay is added before responding
+	Delay time.Duration
+	// Times is the number of requests affected; zero affects every request
+	Times int
+
+	hits int
+}
+
+// AddFault injects a fault. Faults are matched in the order they were added.
+func (s *Server) AddFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// matchFault returns the first fault that applies to the request and counts
+// the hit. The caller must hold the lock.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	path := strings.TrimPrefix(r.URL.Path, APIPrefix)
+	for _, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+			continue
+		}
+		if !strings.HasPrefix(path, f.Path) {
+			continue
+		}
+		if f.Times > 0 && f.hits >= f.Times {
+			continue
+		}
+		f.hits++
+		return f
+	}
+	return nil
+}
+
+// ParseFault parses a fault from its command-line form
+// "[METHOD ]PATH STATUS[xTIMES][+DELAY]", e.g. "POST /pipelines 503x2" or
+// "/runs 0+3s" for a three second delay without an error
+func ParseFault(spec string) (Fault, error) {
+	fields := strings.Fields(spec)
+	var f Fault
+	switch len(fields) {
+	case 2:
+		f.Path = fields[0]
+	case 3:
+		f.Method = strings.ToUpper(fields[0])
+		f.Path = fields[1]
+	default:
+		return f, fmt.Errorf("invalid fault %q: expected \"[METHOD ]PATH STATUS[xTIMES][+DELAY]\"", spec)
+	}
+	if !strings.HasPrefix(f.Path, "/") {
+		return f, fmt.Errorf("invalid fault %q: path must start with /", spec)
+	}
+
+	outcome := fields[len(fields)-1]
+	if rest, delay, ok := strings.Cut(outcome, "+"); ok {
+		d, err := time.ParseDuration(delay)
+		if err != nil {
+			return f, fmt.Errorf("invalid fault %q: %w", spec, err)
+		}
+		f.Delay = d
+		outcome = rest
+	}
+	if rest, times, ok := strings.Cut(outcome, "x"); ok {
+		n, err := strconv.Atoi(times)
+		if err != nil || n < 1 {
+			return f, fmt.Errorf("invalid fault %q: invalid count %q", spec, times)
+		}
+		f.Times = n
+		outcome = rest
+	}
+	status, err := strconv.Atoi(outcome)
+	if err != nil || (status != 0 && (status < 400 || status > 599)) {
+		return f, fmt.Errorf("invalid fault %q: status must be 0 or an error status", spec)
+	}
+	f.Status = status
+	return f, nil
+}
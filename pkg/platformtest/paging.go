@@ -0,0 +1,66 @@
+package platformtest
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+)
+
+// paginate returns the page of items selected by the limit, offset and
+// cursor query parameters, and the cursor of the next page. Without a limit
+// all items are returned, up to MaxPageSize. Cursors are opaque to clients; here they encode
+// the offset of the next page. On invalid parameters it writes a 400
+// response and returns false.
+func paginate[T any](s *Server, w http.ResponseWriter, r *http.Request, items []T) ([]T, string, bool) {
+	query := r.URL.Query()
+
+	limit := len(items)
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid_request", "limit must be a positive integer")
+			return nil, "", false
+		}
+		limit = n
+	}
+	if s.MaxPageSize > 0 {
+		limit = min(limit, s.MaxPageSize)
+	}
+
+	offset := 0
+	if v := query.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid_request", "offset must be a non-negative integer")
+			return nil, "", false
+		}
+		offset = n
+	}
+	if v := query.Get("cursor"); v != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(v)
+		n, convErr := strconv.Atoi(string(decoded))
+		if err != nil || convErr != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid_request", "invalid cursor")
+			return nil, "", false
+		}
+		offset = n
+	}
+
+	if offset >= len(items) {
+		return items[:0], "", true
+	}
+	end := min(offset+limit, len(items))
+	next := ""
+	if end < len(items) && !s.OffsetPaging {
+		next = base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+	}
+	return items[offset:end], next, true
+}
+
+// idLess orders IDs issued by newID by creation, e.g. "run-9" before "run-10"
+func idLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
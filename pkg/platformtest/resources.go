@@ -0,0 +1,637 @@
+package platformtest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+	"github.com/ChronoAIProject/chrono-cli/pkg/pipeline"
+)
+
+// RunStages are the stages of every pipeline run, in order
+var RunStages = []string{"clone", "build", "deploy"}
+
+// storedPipeline is a pipeline with the secret values the API never returns
+type storedPipeline struct {
+	api.Pipeline
+	secrets map[string]string
+}
+
+// storedRun is a run with the stage it is set to fail at
+type storedRun struct {
+	api.Run
+	failStage string
+}
+
+// teamOf returns the team selected by the request, if any
+func teamOf(r *http.Request) string {
+	return r.Header.Get(api.TeamHeader)
+}
+
+// visible checks if a resource of teamID can be seen by the request
+func visible(r *http.Request, teamID string) bool {
+	return teamOf(r) == "" || teamOf(r) == teamID
+}
+
+func (s *Server) handleListProjects(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	projects := []*api.Project{}
+	for _, p := range s.projects {
+		if visible(r, p.TeamID) {
+			projects = append(projects, p)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return idLess(projects[i].ID, projects[j].ID) })
+	page, next, ok := paginate(s, w, r, projects)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"projects": page, "total": len(projects), "next_cursor": next})
+}
+
+func (s *Server) handleCreateProject(w http.ResponseWriter, r *http.Request, userID string) {
+	var req api.CreateProjectRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range s.projects {
+		if p.Name == req.Name && p.TeamID == teamOf(r) {
+			writeError(w, http.StatusConflict, "already_exists", fmt.Sprintf("project %q already exists", req.Name))
+			return
+		}
+	}
+	p := &api.Project{
+		ID:          s.newIDLocked("proj"),
+		Name:        req.Name,
+		Description: req.Description,
+		TeamID:      teamOf(r),
+		CreatedAt:   s.Now(),
+	}
+	s.projects[p.ID] = p
+	writeJSON(w, http.StatusCreated, p)
+}
+
+func (s *Server) handleGetProject(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.projects[r.PathValue("id")]
+	if !ok || !visible(r, p.TeamID) {
+		writeError(w, http.StatusNotFound, "not_found", "project not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) handleDeleteProject(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.projects[r.PathValue("id")]
+	if !ok || !visible(r, p.TeamID) {
+		writeError(w, http.StatusNotFound, "not_found", "project not found")
+		return
+	}
+	for _, pl := range s.pipelines {
+		if pl.ProjectID == p.ID {
+			writeError(w, http.StatusConflict, "has_pipelines", "delete the project's pipelines first")
+			return
+		}
+	}
+	delete(s.projects, p.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListPipelines(w http.ResponseWriter, r *http.Request, userID string) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pipelines := []*api.Pipeline{}
+	for _, p := range s.pipelines {
+		if !visible(r, p.TeamID) ||
+			!matches(query.Get("project_id"), p.ProjectID) ||
+			!matches(query.Get("environment"), p.Environment) ||
+			!matches(query.Get("status"), p.Status) ||
+			!matches(query.Get("repository"), p.Repository) ||
+			!matches(query.Get("branch"), p.Branch) {
+			continue
+		}
+		pipelines = append(pipelines, &p.Pipeline)
+	}
+	sort.Slice(pipelines, func(i, j int) bool { return idLess(pipelines[i].ID, pipelines[j].ID) })
+	page, next, ok := paginate(s, w, r, pipelines)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"pipelines": page, "total": len(pipelines), "next_cursor": next})
+}
+
+// matches checks a value against an optional filter
+func matches(filter, value string) bool {
+	return filter == "" || strings.EqualFold(filter, value)
+}
+
+func (s *Server) handleCreatePipeline(w http.ResponseWriter, r *http.Request, userID string) {
+	var req api.CreatePipelineRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	switch {
+	case req.Name == "" || req.Repository == "" || req.Branch == "":
+		writeError(w, http.StatusBadRequest, "invalid_request", "name, repository and branch are required")
+		return
+	case req.AppType != "frontend" && req.AppType != "backend" && req.AppType != "fullstack":
+		writeError(w, http.StatusBadRequest, "invalid_request", "app_type must be frontend, backend or fullstack")
+		return
+	}
+	if err := pipeline.ValidateAppName(req.AppName); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_app_name", err.Error())
+		return
+	}
+	if req.Environment == "" {
+		req.Environment = "production"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if project, ok := s.projects[req.ProjectID]; !ok || !visible(r, project.TeamID) {
+		writeError(w, http.StatusNotFound, "not_found", "project not found")
+		return
+	}
+	for _, p := range s.pipelines {
+		if p.AppName == req.AppName {
+			writeError(w, http.StatusConflict, "already_exists", fmt.Sprintf("app name %q is already in use", req.AppName))
+			return
+		}
+	}
+
+	now := s.Now()
+	p := &storedPipeline{
+		Pipeline: api.Pipeline{
+			ID:              s.newIDLocked("pipe"),
+			ProjectID:       req.ProjectID,
+			TeamID:          teamOf(r),
+			Name:            req.Name,
+			AppName:         req.AppName,
+			Repository:      req.Repository,
+			Branch:          req.Branch,
+			Environment:     req.Environment,
+			AppType:         req.AppType,
+			BackendPort:     req.BackendPort,
+			FrontendEnvVars: req.FrontendEnvVars,
+			BackendEnvVars:  req.BackendEnvVars,
+			Middleware:      req.Middleware,
+			Status:          "active",
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		},
+		secrets: map[string]string{},
+	}
+	for name, value := range req.Secrets {
+		p.secrets[name] = value
+	}
+	p.SecretNames = sortedKeys(p.secrets)
+	if p.AppType != "backend" {
+		p.FrontendURL = fmt.Sprintf("https://%s.%s", p.AppName, s.AppDomain)
+	}
+	if p.AppType != "frontend" {
+		p.BackendURL = fmt.Sprintf("https://%s-api.%s", p.AppName, s.AppDomain)
+	}
+	s.pipelines[p.ID] = p
+	writeJSON(w, http.StatusCreated, &p.Pipeline)
+}
+
+// pipelineLocked returns the pipeline named in the path, writing a 404
+// response when it does not exist. The caller must hold the lock.
+func (s *Server) pipelineLocked(w http.ResponseWriter, r *http.Request) *storedPipeline {
+	p, ok := s.pipelines[r.PathValue("id")]
+	if !ok || !visible(r, p.TeamID) {
+		writeError(w, http.StatusNotFound, "not_found", "pipeline not found")
+		return nil
+	}
+	return p
+}
+
+func (s *Server) handleGetPipeline(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p := s.pipelineLocked(w, r); p != nil {
+		writeJSON(w, http.StatusOK, &p.Pipeline)
+	}
+}
+
+func (s *Server) handleUpdatePipeline(w http.ResponseWriter, r *http.Request, userID string) {
+	var req api.UpdatePipelineRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pipelineLocked(w, r)
+	if p == nil {
+		return
+	}
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	if req.Branch != nil {
+		p.Branch = *req.Branch
+	}
+	if req.BackendPort != nil {
+		p.BackendPort = *req.BackendPort
+	}
+	if req.Middleware != nil {
+		p.Middleware = *req.Middleware
+	}
+	p.FrontendEnvVars = mergeVars(p.FrontendEnvVars, req.FrontendEnvVars)
+	p.BackendEnvVars = mergeVars(p.BackendEnvVars, req.BackendEnvVars)
+	p.secrets = mergeVars(p.secrets, req.Secrets)
+	p.SecretNames = sortedKeys(p.secrets)
+	p.UpdatedAt = s.Now()
+
+	// Configuration changes restart the running application
+	if d, ok := s.deployments[p.ID]; ok {
+		now := s.Now()
+		d.RestartedAt = &now
+		d.UpdatedAt = now
+	}
+	writeJSON(w, http.StatusOK, &p.Pipeline)
+}
+
+// mergeVars applies updates to vars; empty values remove the variable
+func mergeVars(vars, updates map[string]string) map[string]string {
+	if len(updates) == 0 {
+		return vars
+	}
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	for name, value := range updates {
+		if value == "" {
+			delete(vars, name)
+		} else {
+			vars[name] = value
+		}
+	}
+	return vars
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *Server) handleDeletePipeline(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pipelineLocked(w, r)
+	if p == nil {
+		return
+	}
+	delete(s.pipelines, p.ID)
+	delete(s.deployments, p.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleTriggerRun(w http.ResponseWriter, r *http.Request, userID string) {
+	var req api.TriggerRunRequest
+	if r.ContentLength != 0 && !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pipelineLocked(w, r)
+	if p == nil {
+		return
+	}
+
+	number := 1
+	for _, run := range s.runs {
+		if run.PipelineID == p.ID {
+			number++
+		}
+	}
+	commit := req.CommitSHA
+	if commit == "" {
+		commit = randomString(20)
+	}
+	run := &storedRun{
+		Run: api.Run{
+			ID:          s.newIDLocked("run"),
+			PipelineID:  p.ID,
+			Number:      number,
+			Status:      api.StatusPending,
+			Branch:      p.Branch,
+			CommitSHA:   commit,
+			TriggeredBy: s.users[userID].Email,
+			CreatedAt:   s.Now(),
+		},
+		failStage: s.FailStage,
+	}
+	for _, name := range RunStages {
+		run.Stages = append(run.Stages, api.Stage{Name: name, Status: api.StatusPending})
+	}
+	s.runs[run.ID] = run
+	p.LastRunID = run.ID
+	s.advanceLocked(run)
+	writeJSON(w, http.StatusCreated, &run.Run)
+}
+
+// runLocked returns the run named in the path, brought up to date, writing
+// a 404 response when it does not exist. The caller must hold the lock.
+func (s *Server) runLocked(w http.ResponseWriter, r *http.Request) *storedRun {
+	run, ok := s.runs[r.PathValue("id")]
+	if ok {
+		if p, exists := s.pipelines[run.PipelineID]; exists && !visible(r, p.TeamID) {
+			ok = false
+		}
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "run not found")
+		return nil
+	}
+	s.advanceLocked(run)
+	return run
+}
+
+func (s *Server) handleListRuns(w http.ResponseWriter, r *http.Request, userID string) {
+	pipelineID := r.URL.Query().Get("pipeline_id")
+	status := r.URL.Query().Get("status")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runs := []*api.Run{}
+	for _, run := range s.runs {
+		p, ok := s.pipelines[run.PipelineID]
+		if ok && !visible(r, p.TeamID) {
+			continue
+		}
+		s.advanceLocked(run)
+		if matches(pipelineID, run.PipelineID) && matches(status, run.Status) {
+			runs = append(runs, &run.Run)
+		}
+	}
+	// Most recent first
+	sort.Slice(runs, func(i, j int) bool { return idLess(runs[j].ID, runs[i].ID) })
+	page, next, ok := paginate(s, w, r, runs)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"runs": page, "total": len(runs), "next_cursor": next})
+}
+
+func (s *Server) handleGetRun(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if run := s.runLocked(w, r); run != nil {
+		writeJSON(w, http.StatusOK, &run.Run)
+	}
+}
+
+func (s *Server) handleCancelRun(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run := s.runLocked(w, r)
+	if run == nil {
+		return
+	}
+	if run.Finished() {
+		writeError(w, http.StatusConflict, "run_finished", fmt.Sprintf("run already %s", run.Status))
+		return
+	}
+
+	now := s.Now()
+	run.Status = api.StatusCancelled
+	run.FinishedAt = &now
+	for i := range run.Stages {
+		switch run.Stages[i].Status {
+		case api.StatusRunning:
+			run.Stages[i].Status = api.StatusCancelled
+			run.Stages[i].FinishedAt = &now
+		case api.StatusPending:
+			run.Stages[i].Status = api.StatusSkipped
+		}
+	}
+	writeJSON(w, http.StatusOK, &run.Run)
+}
+
+func (s *Server) handleRunLogs(w http.ResponseWriter, r *http.Request, userID string) {
+	stage := r.URL.Query().Get("stage")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run := s.runLocked(w, r)
+	if run == nil {
+		return
+	}
+
+	logs := api.RunLogs{RunID: run.ID, Stages: []api.StageLog{}}
+	for _, st := range run.Stages {
+		if !matches(stage, st.Name) {
+			continue
+		}
+		logs.Stages = append(logs.Stages, api.StageLog{Name: st.Name, Status: st.Status, Log: stageLog(run, st)})
+	}
+	writeJSON(w, http.StatusOK, logs)
+}
+
+// advanceLocked moves a run forward according to the time elapsed since it
+// was triggered; each stage takes StageDuration. The caller must hold the lock.
+func (s *Server) advanceLocked(run *storedRun) {
+	if run.Finished() {
+		return
+	}
+
+	now := s.Now()
+	for i := range run.Stages {
+		stage := &run.Stages[i]
+		start := run.CreatedAt.Add(time.Duration(i) * s.StageDuration)
+		end := start.Add(s.StageDuration)
+		if now.Before(start) {
+			break
+		}
+
+		stage.StartedAt = &start
+		if run.StartedAt == nil {
+			run.StartedAt = &start
+		}
+		run.Status = api.StatusRunning
+		if now.Before(end) {
+			stage.Status = api.StatusRunning
+			return
+		}
+
+		stage.FinishedAt = &end
+		if stage.Name == run.failStage {
+			stage.Status = api.StatusFailed
+			for j := i + 1; j < len(run.Stages); j++ {
+				run.Stages[j].Status = api.StatusSkipped
+			}
+			run.Status = api.StatusFailed
+			run.Error = fmt.Sprintf("stage %s failed", stage.Name)
+			run.FinishedAt = &end
+			return
+		}
+		stage.Status = api.StatusSucceeded
+	}
+
+	if run.Stages[len(run.Stages)-1].Status != api.StatusSucceeded {
+		return
+	}
+	end := *run.Stages[len(run.Stages)-1].FinishedAt
+	run.Status = api.StatusSucceeded
+	run.FinishedAt = &end
+
+	if p, ok := s.pipelines[run.PipelineID]; ok {
+		s.deployments[p.ID] = &api.Deployment{
+			PipelineID:    p.ID,
+			AppName:       p.AppName,
+			Status:        api.StatusRunning,
+			Replicas:      2,
+			ReadyReplicas: 2,
+			Image:         fmt.Sprintf("registry.%s/%s:%s", s.AppDomain, p.AppName, run.CommitSHA[:7]),
+			RunID:         run.ID,
+			UpdatedAt:     end,
+		}
+	}
+}
+
+// stageLog returns the fake log of a stage so far
+func stageLog(run *storedRun, stage api.Stage) string {
+	var lines []string
+	switch stage.Status {
+	case api.StatusPending, api.StatusSkipped:
+		return ""
+	}
+	switch stage.Name {
+	case "clone":
+		lines = []string{
+			fmt.Sprintf("Cloning branch %s", run.Branch),
+			fmt.Sprintf("HEAD is now at %s", run.CommitSHA[:7]),
+		}
+	case "build":
+		lines = []string{
+			"Step 1/4 : FROM node:20-alpine",
+			"Step 2/4 : COPY . .",
+			"Step 3/4 : RUN npm ci && npm run build",
+			"Step 4/4 : CMD [\"npm\", \"start\"]",
+		}
+	case "deploy":
+		lines = []string{
+			"Applying manifests",
+			"Waiting for rollout to finish: 0 of 2 updated replicas are available",
+		}
+	}
+	switch stage.Status {
+	case api.StatusSucceeded:
+		lines = append(lines, fmt.Sprintf("Stage %s succeeded", stage.Name))
+	case api.StatusFailed:
+		lines = append(lines, fmt.Sprintf("error: stage %s failed (injected by platformtest)", stage.Name))
+	case api.StatusCancelled:
+		lines = append(lines, "Cancelled")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// deploymentLocked returns the deployment of the pipeline in the path,
+// writing a 404 response when there is none. The caller must hold the lock.
+func (s *Server) deploymentLocked(w http.ResponseWriter, r *http.Request) (*storedPipeline, *api.Deployment) {
+	p := s.pipelineLocked(w, r)
+	if p == nil {
+		return nil, nil
+	}
+	if p.LastRunID != "" {
+		s.advanceLocked(s.runs[p.LastRunID])
+	}
+	d, ok := s.deployments[p.ID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_deployed", "the pipeline has no successful run yet")
+		return nil, nil
+	}
+	return p, d
+}
+
+func (s *Server) handleGetDeployment(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, d := s.deploymentLocked(w, r); d != nil {
+		writeJSON(w, http.StatusOK, d)
+	}
+}
+
+func (s *Server) handleRestartDeployment(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, d := s.deploymentLocked(w, r)
+	if d == nil {
+		return
+	}
+	now := s.Now()
+	d.RestartedAt = &now
+	d.UpdatedAt = now
+	writeJSON(w, http.StatusOK, d)
+}
+
+func (s *Server) handleDeploymentLogs(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, d := s.deploymentLocked(w, r)
+	if d == nil {
+		return
+	}
+
+	logs := api.DeploymentLogs{PipelineID: p.ID}
+	for i := 0; i < d.Replicas; i++ {
+		logs.Pods = append(logs.Pods, api.PodLog{
+			Pod: fmt.Sprintf("%s-%d", p.AppName, i),
+			Log: fmt.Sprintf("Server listening on port %d\nGET /health 200\n", max(p.BackendPort, 8080)),
+		})
+	}
+	writeJSON(w, http.StatusOK, logs)
+}
+
+func (s *Server) handleDeploymentEnv(w http.ResponseWriter, r *http.Request, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, d := s.deploymentLocked(w, r)
+	if d == nil {
+		return
+	}
+
+	env := map[string]string{}
+	for name, value := range p.BackendEnvVars {
+		env[name] = value
+	}
+	for name := range p.secrets {
+		env[name] = api.MaskedValue
+	}
+	for _, m := range p.Middleware {
+		switch m {
+		case "mongodb":
+			env["MONGODB_URI"] = api.MaskedValue
+			env["MONGODB_DATABASE"] = p.AppName
+		case "redis":
+			env["REDIS_URL"] = api.MaskedValue
+		case "postgresql", "postgres":
+			env["DATABASE_URL"] = api.MaskedValue
+		}
+	}
+	writeJSON(w, http.StatusOK, api.DeploymentEnv{PipelineID: p.ID, Pod: p.AppName + "-0", Env: env})
+}
@@ -0,0 +1,326 @@
+// Package platformtest provides an in-memory fake of the Developer Platform API
+// for integration tests, offline demos and workshops.
+//
+// The fake implements the device flow, sessions, API tokens, teams, skills,
+// MCP info and the project, pipeline, run and deployment lifecycle. Faults
+// and latency can be injected per route to exercise error handling.
+package platformtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+)
+
+// APIPrefix is the path the API is served under, as on the real platform
+const APIPrefix = "/api/v1"
+
+// Defaults of a new server
+const (
+	DefaultStageDuration  = 2 * time.Second
+	DefaultAccessTokenTTL = time.Hour
+	DefaultAppDomain      = "apps.example.com"
+	DefaultVersion        = "0.0.0-fake"
+)
+
+// Default identity seeded into a new server
+const (
+	DefaultUserID    = "user-1"
+	DefaultUserEmail = "dev@example.com"
+	DefaultTeamID    = "team-1"
+)
+
+// Server is an in-memory Developer Platform. The zero value is not usable;
+// create one with New or NewServer. Exported fields may be changed between
+// requests; they are read under the server lock.
+type Server struct {
+	// AutoApprove approves device flow requests on the first poll
+	AutoApprove bool
+	// StageDuration is how long each stage of a pipeline run takes.
+	// With zero, runs finish as soon as they are read.
+	StageDuration time.Duration
+	// FailStage makes runs triggered while it is set fail at the named stage
+	FailStage string
+	// Latency is added to every request
+	Latency time.Duration
+	// AccessTokenTTL is the lifetime of issued access tokens
+	AccessTokenTTL time.Duration
+	// AppDomain is the domain deployed applications are served under
+	AppDomain string
+	// Version is reported by /mcp/info
+	Version string
+	// MinCLIVersion and RecommendedCLIVersion are advertised in response
+	// headers and by /mcp/info. CLIs older than MinCLIVersion are rejected
+	// with 426 Upgrade Required.
+	MinCLIVersion         string
+	RecommendedCLIVersion string
+	// Skills maps skill names to their markdown content
+	Skills map[string]string
+	// Now returns the current time; replaced in tests to control run progress
+	Now func() time.Time
+	// MaxPageSize caps the number of items list endpoints return per page,
+	// whatever limit is requested; 0 means no cap
+	MaxPageSize int
+	// OffsetPaging makes list endpoints page by offset only, without next
+	// cursors, like older platform versions
+	OffsetPaging bool
+
+	// URL is the API base URL when started with NewServer, e.g. http://127.0.0.1:1234/api/v1
+	URL string
+
+	mu              sync.Mutex
+	httpServer      *httptest.Server
+	mux             *http.ServeMux
+	nextID          int
+	users           map[string]*api.User
+	serviceAccounts map[string]string
+	devices         map[string]*deviceRequest
+	sessions        map[string]*session
+	refreshTokens   map[string]string
+	apiTokens       map[string]*apiToken
+	projects        map[string]*api.Project
+	pipelines       map[string]*storedPipeline
+	runs            map[string]*storedRun
+	deployments     map[string]*api.Deployment
+	faults          []*Fault
+	requests        []string
+}
+
+// New creates a server seeded with a user who belongs to one team.
+// Serve it with any http.Server, or use NewServer for tests.
+func New() *Server {
+	s := &Server{
+		StageDuration:  DefaultStageDuration,
+		AccessTokenTTL: DefaultAccessTokenTTL,
+		AppDomain:      DefaultAppDomain,
+		Version:        DefaultVersion,
+		Skills:         defaultSkills(),
+		Now:            time.Now,
+
+		users:           map[string]*api.User{},
+		serviceAccounts: map[string]string{},
+		devices:         map[string]*deviceRequest{},
+		sessions:        map[string]*session{},
+		refreshTokens:   map[string]string{},
+		apiTokens:       map[string]*apiToken{},
+		projects:        map[string]*api.Project{},
+		pipelines:       map[string]*storedPipeline{},
+		runs:            map[string]*storedRun{},
+		deployments:     map[string]*api.Deployment{},
+	}
+	s.users[DefaultUserID] = &api.User{
+		ID:    DefaultUserID,
+		Email: DefaultUserEmail,
+		Name:  "Dev User",
+		Role:  "developer",
+		Teams: []api.Team{{ID: DefaultTeamID, Name: "Platform Team", Role: "owner", MemberCount: 1}},
+	}
+	s.routes()
+	return s
+}
+
+// NewServer starts a server on a local port. Close it when done.
+func NewServer() *Server {
+	s := New()
+	s.httpServer = httptest.NewServer(s)
+	s.URL = s.httpServer.URL + APIPrefix
+	return s
+}
+
+// Close shuts down a server started with NewServer
+func (s *Server) Close() {
+	if s.httpServer != nil {
+		s.httpServer.Close()
+	}
+}
+
+// ServeHTTP implements http.Handler. The API is served under APIPrefix and
+// the device approval page under /activate.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := s.newID("req")
+	w.Header().Set(api.RequestIDHeader, requestID)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, APIPrefix))
+	latency := s.Latency
+	fault := s.matchFault(r)
+	compat := api.Compatibility{MinVersion: s.MinCLIVersion, RecommendedVersion: s.RecommendedCLIVersion}
+	s.mu.Unlock()
+
+	if compat.MinVersion != "" {
+		w.Header().Set(api.MinCLIVersionHeader, compat.MinVersion)
+	}
+	if compat.RecommendedVersion != "" {
+		w.Header().Set(api.RecommendedCLIVersionHeader, compat.RecommendedVersion)
+	}
+
+	if fault != nil {
+		latency += fault.Delay
+	}
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if fault != nil && fault.Status != 0 {
+		if fault.Status == http.StatusTooManyRequests || fault.Status == http.StatusServiceUnavailable {
+			w.Header().Set("Retry-After", "1")
+		}
+		writeError(w, fault.Status, "injected_fault", "fault injected by platformtest")
+		return
+	}
+	if compat.Check(cliVersion(r.UserAgent())) == api.VersionUnsupported {
+		writeError(w, http.StatusUpgradeRequired, "cli_version_unsupported", "this CLI version is no longer supported; minimum version is "+compat.MinVersion)
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// Requests returns the requests served so far as "METHOD /path", with the
+// API prefix removed
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+// routes registers the API handlers
+func (s *Server) routes() {
+	s.mux = http.NewServeMux()
+	handle := func(pattern string, handler http.HandlerFunc) {
+		method, path, _ := strings.Cut(pattern, " ")
+		s.mux.HandleFunc(method+" "+APIPrefix+path, handler)
+	}
+
+	s.mux.HandleFunc("GET /activate", s.handleActivate)
+
+	handle("POST /auth/device/start", s.handleDeviceStart)
+	handle("POST /auth/device/poll", s.handleDevicePoll)
+	handle("POST /auth/token", s.handleToken)
+	handle("POST /auth/refresh", s.handleRefresh)
+	handle("POST /auth/logout", s.handleLogout)
+	handle("GET /auth/me", s.authenticated(s.handleMe))
+	handle("GET /auth/tokens", s.authenticated(s.handleListTokens))
+	handle("POST /auth/tokens", s.authenticated(s.handleCreateToken))
+	handle("GET /auth/tokens/{id}", s.authenticated(s.handleGetToken))
+	handle("DELETE /auth/tokens/{id}", s.authenticated(s.handleRevokeToken))
+	handle("GET /teams", s.authenticated(s.handleListTeams))
+	handle("GET /teams/{id}", s.authenticated(s.handleGetTeam))
+
+	handle("GET /mcp/info", s.handleMCPInfo)
+	handle("GET /skills", s.handleListSkills)
+	handle("GET /skills/{name...}", s.handleGetSkill)
+
+	handle("GET /projects", s.authenticated(s.handleListProjects))
+	handle("POST /projects", s.authenticated(s.handleCreateProject))
+	handle("GET /projects/{id}", s.authenticated(s.handleGetProject))
+	handle("DELETE /projects/{id}", s.authenticated(s.handleDeleteProject))
+
+	handle("GET /pipelines", s.authenticated(s.handleListPipelines))
+	handle("POST /pipelines", s.authenticated(s.handleCreatePipeline))
+	handle("GET /pipelines/{id}", s.authenticated(s.handleGetPipeline))
+	handle("PATCH /pipelines/{id}", s.authenticated(s.handleUpdatePipeline))
+	handle("DELETE /pipelines/{id}", s.authenticated(s.handleDeletePipeline))
+	handle("POST /pipelines/{id}/runs", s.authenticated(s.handleTriggerRun))
+	handle("GET /pipelines/{id}/deployment", s.authenticated(s.handleGetDeployment))
+	handle("POST /pipelines/{id}/deployment/restart", s.authenticated(s.handleRestartDeployment))
+	handle("GET /pipelines/{id}/deployment/logs", s.authenticated(s.handleDeploymentLogs))
+	handle("GET /pipelines/{id}/deployment/env", s.authenticated(s.handleDeploymentEnv))
+
+	handle("GET /runs", s.authenticated(s.handleListRuns))
+	handle("GET /runs/{id}", s.authenticated(s.handleGetRun))
+	handle("POST /runs/{id}/cancel", s.authenticated(s.handleCancelRun))
+	handle("GET /runs/{id}/logs", s.authenticated(s.handleRunLogs))
+
+	s.mux.HandleFunc(APIPrefix+"/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	})
+}
+
+// newID returns a unique identifier with the given prefix
+func (s *Server) newID(prefix string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.newIDLocked(prefix)
+}
+
+// newIDLocked is newID for callers holding the lock
+func (s *Server) newIDLocked(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", prefix, s.nextID)
+}
+
+// randomString returns n random bytes, hex encoded
+func randomString(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// cliVersion extracts the version from a "chrono-cli/<version> ..." User-Agent
+func cliVersion(userAgent string) string {
+	product, _, _ := strings.Cut(userAgent, " ")
+	name, version, _ := strings.Cut(product, "/")
+	if name != "chrono-cli" {
+		return ""
+	}
+	return version
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+// writeError writes an RFC 7807 problem response with a machine-readable code
+func writeError(w http.ResponseWriter, status int, code, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":       "about:blank",
+		"title":      http.StatusText(status),
+		"status":     status,
+		"detail":     detail,
+		"code":       code,
+		"request_id": w.Header().Get(api.RequestIDHeader),
+	})
+}
+
+// writeOAuthError writes an OAuth 2.0 error response (RFC 6749 section 5.2)
+func writeOAuthError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{"error": code, "error_description": description})
+}
+
+// decode reads a JSON request body, writing a 400 response on failure
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("invalid JSON body: %v", err))
+		return false
+	}
+	return true
+}
+
+// defaultSkills returns placeholder skills served by a new server
+func defaultSkills() map[string]string {
+	return map[string]string{
+		"chrono-deploy": "---\nname: chrono-deploy\ndescription: Deploy the current project (platformtest)\n---\n\n# Deploy\n\nRun `chrono deploy`.\n",
+		"chrono-setup":  "---\nname: chrono-setup\ndescription: Set up the Chrono CLI (platformtest)\n---\n\n# Setup\n\nRun `chrono login` and `chrono mcp-setup`.\n",
+	}
+}
@@ -0,0 +1,542 @@
+package platformtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChronoAIProject/chrono-cli/pkg/api"
+)
+
+// fakeClock is a controllable time source
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newClient returns a client authenticated against the server, without retries
+func newClient(s *Server) *api.Client {
+	client := api.NewClient(s.URL)
+	client.SetAuthToken(s.AccessToken())
+	client.SetRetryPolicy(api.RetryPolicy{MaxAttempts: 1})
+	return client
+}
+
+func TestServer_DeviceFlow(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := api.NewClient(s.URL)
+	ctx := context.Background()
+
+	start, err := client.StartDeviceFlow(ctx)
+	if err != nil {
+		t.Fatalf("StartDeviceFlow() failed: %v", err)
+	}
+	if !strings.HasSuffix(start.VerificationURIComplete, "/activate?user_code="+start.UserCode) {
+		t.Errorf("VerificationURIComplete = %v", start.VerificationURIComplete)
+	}
+
+	poll, err := client.PollDeviceFlow(ctx, start.DeviceCode)
+	if err == nil || poll.Status != "authorization_pending" {
+		t.Fatalf("PollDeviceFlow() = %+v, %v, want authorization_pending", poll, err)
+	}
+
+	// Approve through the activation page, as a user would
+	resp, err := http.Get(start.VerificationURIComplete)
+	if err != nil {
+		t.Fatalf("Failed to open activation page: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Activation page status = %d", resp.StatusCode)
+	}
+
+	poll, err = client.PollDeviceFlow(ctx, start.DeviceCode)
+	if err != nil {
+		t.Fatalf("PollDeviceFlow() failed: %v", err)
+	}
+	if poll.AccessToken == "" || poll.RefreshToken == "" {
+		t.Fatalf("PollDeviceFlow() = %+v, want tokens", poll)
+	}
+
+	client.SetAuthToken(poll.AccessToken)
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentUser() failed: %v", err)
+	}
+	if user.Email != DefaultUserEmail {
+		t.Errorf("Email = %v, want %v", user.Email, DefaultUserEmail)
+	}
+
+	// Refresh tokens are rotated
+	refreshed, err := client.RefreshSession(ctx, poll.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshSession() failed: %v", err)
+	}
+	if refreshed.RefreshToken == poll.RefreshToken {
+		t.Error("RefreshSession() did not rotate the refresh token")
+	}
+	if _, err := client.RefreshSession(ctx, poll.RefreshToken); !api.IsUnauthorized(err) {
+		t.Errorf("Reusing a refresh token: error = %v, want 401", err)
+	}
+}
+
+func TestServer_DeviceFlowAutoApproveAndDeny(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := api.NewClient(s.URL)
+	ctx := context.Background()
+
+	start, _ := client.StartDeviceFlow(ctx)
+	if err := s.DenyDevice(start.UserCode); err != nil {
+		t.Fatalf("DenyDevice() failed: %v", err)
+	}
+	poll, err := client.PollDeviceFlow(ctx, start.DeviceCode)
+	if err == nil || poll.Status != "access_denied" {
+		t.Errorf("PollDeviceFlow() = %+v, %v, want access_denied", poll, err)
+	}
+
+	s.AutoApprove = true
+	start, _ = client.StartDeviceFlow(ctx)
+	poll, err = client.PollDeviceFlow(ctx, start.DeviceCode)
+	if err != nil || poll.AccessToken == "" {
+		t.Errorf("PollDeviceFlow() = %+v, %v, want approval", poll, err)
+	}
+}
+
+func TestServer_Tokens(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(s)
+	ctx := context.Background()
+
+	created, err := client.CreateToken(ctx, &api.CreateAPITokenRequest{Name: "CI", Scope: "personal", ExpiresIn: 3600})
+	if err != nil {
+		t.Fatalf("CreateToken() failed: %v", err)
+	}
+	if !strings.HasPrefix(created.Token, "dp_") || !strings.HasPrefix(created.Token, strings.TrimSuffix(created.TokenPrefix, "...")) {
+		t.Errorf("Token = %v, prefix %v", created.Token, created.TokenPrefix)
+	}
+
+	tokenClient := newClient(s)
+	tokenClient.SetAPIToken(created.Token)
+	if _, err := tokenClient.GetCurrentUser(ctx); err != nil {
+		t.Fatalf("GetCurrentUser() with API token failed: %v", err)
+	}
+
+	list, err := client.ListTokens(ctx)
+	if err != nil {
+		t.Fatalf("ListTokens() failed: %v", err)
+	}
+	if list.Total != 1 || list.Tokens[0].LastUsedAt == nil {
+		t.Errorf("ListTokens() = %+v, want one used token", list)
+	}
+
+	if err := client.RevokeToken(ctx, created.ID); err != nil {
+		t.Fatalf("RevokeToken() failed: %v", err)
+	}
+	if _, err := tokenClient.GetCurrentUser(ctx); !api.IsUnauthorized(err) {
+		t.Errorf("GetCurrentUser() with revoked token: error = %v, want 401", err)
+	}
+}
+
+func TestServer_ServiceAccount(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddServiceAccount("ci-bot", "ci-secret")
+	client := api.NewClient(s.URL)
+	ctx := context.Background()
+
+	if _, err := client.ClientCredentialsLogin(ctx, "ci-bot", "wrong"); !api.IsUnauthorized(err) {
+		t.Errorf("ClientCredentialsLogin() with wrong secret: error = %v, want 401", err)
+	}
+	resp, err := client.ClientCredentialsLogin(ctx, "ci-bot", "ci-secret")
+	if err != nil {
+		t.Fatalf("ClientCredentialsLogin() failed: %v", err)
+	}
+	if resp.AccessToken == "" {
+		t.Error("ClientCredentialsLogin() returned no access token")
+	}
+}
+
+func TestServer_SkillsAndMCPInfo(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.Version = "1.2.3"
+	client := api.NewClient(s.URL)
+	ctx := context.Background()
+
+	info, err := client.GetMCPInfo(ctx)
+	if err != nil {
+		t.Fatalf("GetMCPInfo() failed: %v", err)
+	}
+	if info.Version != "1.2.3" {
+		t.Errorf("Version = %v, want 1.2.3", info.Version)
+	}
+
+	skills, err := client.ListSkills(ctx)
+	if err != nil {
+		t.Fatalf("ListSkills() failed: %v", err)
+	}
+	if len(skills.Skills) != len(s.Skills) {
+		t.Fatalf("ListSkills() returned %d skills, want %d", len(skills.Skills), len(s.Skills))
+	}
+	content, err := client.DownloadSkill(ctx, skills.Skills[0].Name)
+	if err != nil {
+		t.Fatalf("DownloadSkill() failed: %v", err)
+	}
+	if content != s.Skills[skills.Skills[0].Name] {
+		t.Errorf("DownloadSkill() = %q", content)
+	}
+}
+
+func TestServer_RunLifecycle(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s := NewServer()
+	defer s.Close()
+	s.Now = clock.Now
+	s.StageDuration = time.Minute
+	client := newClient(s)
+	ctx := context.Background()
+
+	project, err := client.CreateProject(ctx, &api.CreateProjectRequest{Name: "shop"})
+	if err != nil {
+		t.Fatalf("CreateProject() failed: %v", err)
+	}
+
+	invalid := &api.CreatePipelineRequest{ProjectID: project.ID, Name: "shop", AppName: "Shop_Main", Repository: "acme/shop", Branch: "main", AppType: api.AppTypeFullstack}
+	if _, err := client.CreatePipeline(ctx, invalid); err == nil {
+		t.Error("Creating a pipeline with an invalid app name succeeded")
+	}
+
+	req := &api.CreatePipelineRequest{
+		ProjectID:  project.ID,
+		Name:       "shop-main",
+		AppName:    "shop-main",
+		Repository: "acme/shop",
+		Branch:     "main",
+		AppType:    api.AppTypeFullstack,
+		Secrets:    map[string]string{"JWT_SECRET": "s3cret"},
+		Middleware: []string{"mongodb"},
+	}
+	pipeline, err := client.CreatePipeline(ctx, req)
+	if err != nil {
+		t.Fatalf("CreatePipeline() failed: %v", err)
+	}
+	if pipeline.FrontendURL != "https://shop-main."+DefaultAppDomain || pipeline.BackendURL != "https://shop-main-api."+DefaultAppDomain {
+		t.Errorf("URLs = %v, %v", pipeline.FrontendURL, pipeline.BackendURL)
+	}
+	if !reflect.DeepEqual(pipeline.SecretNames, []string{"JWT_SECRET"}) {
+		t.Errorf("SecretNames = %v", pipeline.SecretNames)
+	}
+	if _, err := client.CreatePipeline(ctx, req); !api.IsConflict(err) {
+		t.Errorf("Duplicate app name: error = %v, want 409", err)
+	}
+
+	run, err := client.TriggerRun(ctx, pipeline.ID, &api.TriggerRunRequest{CommitSHA: "0123456789abcdef"})
+	if err != nil {
+		t.Fatalf("TriggerRun() failed: %v", err)
+	}
+	if run.Status != api.StatusRunning || run.Stages[0].Status != api.StatusRunning || run.TriggeredBy != DefaultUserEmail {
+		t.Errorf("Run = %+v, want first stage running", run)
+	}
+	if _, err := client.GetDeployment(ctx, pipeline.ID); !api.IsNotFound(err) {
+		t.Errorf("Deployment before the first run: error = %v, want 404", err)
+	}
+
+	clock.Advance(90 * time.Second)
+	if run, err = client.GetRun(ctx, run.ID); err != nil {
+		t.Fatalf("GetRun() failed: %v", err)
+	}
+	if run.Stages[0].Status != api.StatusSucceeded || run.Stages[1].Status != api.StatusRunning {
+		t.Errorf("Stages = %+v, want clone done and build running", run.Stages)
+	}
+
+	clock.Advance(2 * time.Minute)
+	if run, err = client.GetRun(ctx, run.ID); err != nil {
+		t.Fatalf("GetRun() failed: %v", err)
+	}
+	if run.Status != api.StatusSucceeded || run.FinishedAt == nil || !run.Finished() {
+		t.Errorf("Run = %+v, want succeeded", run)
+	}
+
+	logs, err := client.GetRunLogs(ctx, run.ID, "build")
+	if err != nil {
+		t.Fatalf("GetRunLogs() failed: %v", err)
+	}
+	if len(logs.Stages) != 1 || !strings.Contains(logs.Stages[0].Log, "Stage build succeeded") {
+		t.Errorf("Logs = %+v", logs)
+	}
+
+	deployment, err := client.GetDeployment(ctx, pipeline.ID)
+	if err != nil {
+		t.Fatalf("GetDeployment() failed: %v", err)
+	}
+	if deployment.RunID != run.ID || deployment.ReadyReplicas != deployment.Replicas {
+		t.Errorf("Deployment = %+v", deployment)
+	}
+	env, err := client.GetDeploymentEnv(ctx, pipeline.ID)
+	if err != nil {
+		t.Fatalf("GetDeploymentEnv() failed: %v", err)
+	}
+	if env.Env["JWT_SECRET"] != api.MaskedValue || env.Env["MONGODB_URI"] == "" {
+		t.Errorf("Env = %v, want masked secret and middleware", env.Env)
+	}
+
+	port := 9000
+	updated, err := client.UpdatePipeline(ctx, pipeline.ID, &api.UpdatePipelineRequest{BackendPort: &port})
+	if err != nil {
+		t.Fatalf("UpdatePipeline() failed: %v", err)
+	}
+	if updated.BackendPort != 9000 || !reflect.DeepEqual(updated.Middleware, []string{"mongodb"}) {
+		t.Errorf("BackendPort = %d, Middleware = %v, want 9000 and middleware unchanged", updated.BackendPort, updated.Middleware)
+	}
+	if updated, err = client.UpdatePipeline(ctx, pipeline.ID, &api.UpdatePipelineRequest{Middleware: &[]string{}}); err != nil {
+		t.Fatalf("UpdatePipeline() failed: %v", err)
+	}
+	if len(updated.Middleware) != 0 {
+		t.Errorf("Middleware = %v, want all removed", updated.Middleware)
+	}
+	if deployment, err = client.GetDeployment(ctx, pipeline.ID); err != nil || deployment.RestartedAt == nil {
+		t.Errorf("Deployment after update = %+v, %v, want restarted", deployment, err)
+	}
+
+	// Failing and cancelled runs
+	s.FailStage = "build"
+	if run, err = client.TriggerRun(ctx, pipeline.ID, nil); err != nil {
+		t.Fatalf("TriggerRun() failed: %v", err)
+	}
+	clock.Advance(10 * time.Minute)
+	if run, err = client.GetRun(ctx, run.ID); err != nil {
+		t.Fatalf("GetRun() failed: %v", err)
+	}
+	if run.Status != api.StatusFailed || run.Stages[1].Status != api.StatusFailed || run.Stages[2].Status != api.StatusSkipped {
+		t.Errorf("Run = %+v, want failed at build", run)
+	}
+
+	if run, err = client.TriggerRun(ctx, pipeline.ID, nil); err != nil {
+		t.Fatalf("TriggerRun() failed: %v", err)
+	}
+	if run, err = client.CancelRun(ctx, run.ID); err != nil {
+		t.Fatalf("CancelRun() failed: %v", err)
+	}
+	if run.Status != api.StatusCancelled {
+		t.Errorf("Status = %v, want cancelled", run.Status)
+	}
+	if _, err := client.CancelRun(ctx, run.ID); !api.IsConflict(err) {
+		t.Errorf("Cancelling a finished run: error = %v, want 409", err)
+	}
+
+	runs, err := client.ListRuns(ctx, api.RunFilter{PipelineID: pipeline.ID})
+	if err != nil {
+		t.Fatalf("ListRuns() failed: %v", err)
+	}
+	if len(runs.Runs) != 3 || runs.Runs[0].ID != run.ID {
+		t.Errorf("ListRuns() = %d runs, want 3 with the cancelled run first", len(runs.Runs))
+	}
+	pipelines, err := client.ListPipelines(ctx, api.PipelineFilter{Repository: "acme/shop", Branch: "main"})
+	if err != nil || len(pipelines.Pipelines) != 1 {
+		t.Errorf("ListPipelines() = %+v, %v, want 1 pipeline", pipelines, err)
+	}
+
+	if err := client.DeleteProject(ctx, project.ID); !api.IsConflict(err) {
+		t.Errorf("Deleting a project with pipelines: error = %v, want 409", err)
+	}
+	if err := client.DeletePipeline(ctx, pipeline.ID); err != nil {
+		t.Fatalf("DeletePipeline() failed: %v", err)
+	}
+	if err := client.DeleteProject(ctx, project.ID); err != nil {
+		t.Errorf("DeleteProject() failed: %v", err)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(s)
+	client.SetRetryPolicy(api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	ctx := context.Background()
+
+	s.AddFault(Fault{Method: "GET", Path: "/auth/me", Status: http.StatusBadGateway, Times: 2})
+	if _, err := client.GetCurrentUser(ctx); err != nil {
+		t.Fatalf("GetCurrentUser() failed after retries: %v", err)
+	}
+	if got := len(s.Requests()); got != 3 {
+		t.Errorf("Server saw %d requests, want 3", got)
+	}
+
+	s.AddFault(Fault{Path: "/teams", Status: http.StatusForbidden})
+	_, err := client.ListTeams(ctx)
+	if !api.IsForbidden(err) {
+		t.Fatalf("ListTeams() error = %v, want 403", err)
+	}
+	if !strings.Contains(err.Error(), "injected_fault") {
+		t.Errorf("Error = %v, want injected_fault code", err)
+	}
+
+	s.ClearFaults()
+	s.AddFault(Fault{Delay: 200 * time.Millisecond})
+	client.SetTimeout(50 * time.Millisecond)
+	client.SetRetryPolicy(api.RetryPolicy{MaxAttempts: 1})
+	if _, err := client.ListTeams(ctx); err == nil {
+		t.Error("ListTeams() succeeded despite injected latency")
+	}
+}
+
+func TestParseFault(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Fault
+		wantErr bool
+	}{
+		{spec: "POST /pipelines 503x2", want: Fault{Method: "POST", Path: "/pipelines", Status: 503, Times: 2}},
+		{spec: "/runs 0+3s", want: Fault{Path: "/runs", Delay: 3 * time.Second}},
+		{spec: "get /auth/me 500+100msx1", wantErr: true},
+		{spec: "get /auth/me 500x1+100ms", want: Fault{Method: "GET", Path: "/auth/me", Status: 500, Times: 1, Delay: 100 * time.Millisecond}},
+		{spec: "/runs 200", wantErr: true},
+		{spec: "runs 500", wantErr: true},
+		{spec: "500", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseFault(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFault() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseFault() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_RejectsMissingToken(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get(api.RequestIDHeader) == "" {
+		t.Errorf("Status = %d, body %s, want 401 with a request ID", resp.StatusCode, body)
+	}
+}
+
+func TestServer_CLIVersion(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.MinCLIVersion = "1.2.0"
+	s.RecommendedCLIVersion = "1.5.0"
+
+	client := newClient(s)
+	client.SetVersion("1.3.0")
+	info, err := client.GetMCPInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetMCPInfo() failed: %v", err)
+	}
+	if got := info.Compatibility().Check("1.3.0"); got != api.VersionOutdated {
+		t.Errorf("Check() = %d, want outdated", got)
+	}
+
+	// The server serves browsers but rejects CLIs below the minimum itself
+	resp, err := http.Get(s.URL + "/mcp/info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("request without CLI User-Agent: status %d, want 200", resp.StatusCode)
+	}
+	req, _ := http.NewRequest(http.MethodGet, s.URL+"/mcp/info", nil)
+	req.Header.Set("User-Agent", api.UserAgent("1.1.0"))
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUpgradeRequired {
+		t.Errorf("request from CLI 1.1.0: status %d, want 426", resp.StatusCode)
+	}
+	if got := resp.Header.Get(api.MinCLIVersionHeader); got != "1.2.0" {
+		t.Errorf("%s = %q", api.MinCLIVersionHeader, got)
+	}
+}
+
+func TestServer_Pagination(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(s)
+
+	ctx := context.Background()
+	for i := range 7 {
+		if _, err := client.CreateToken(ctx, &api.CreateAPITokenRequest{Name: fmt.Sprintf("token-%d", i)}); err != nil {
+			t.Fatalf("CreateToken() failed: %v", err)
+		}
+	}
+
+	page, err := client.ListTokensPage(ctx, api.PageRequest{Limit: 3})
+	if err != nil {
+		t.Fatalf("ListTokensPage() failed: %v", err)
+	}
+	if len(page.Tokens) != 3 || page.Total != 7 || page.NextCursor == "" {
+		t.Errorf("first page: %d tokens, total %d, cursor %q", len(page.Tokens), page.Total, page.NextCursor)
+	}
+
+	it := client.IterTokens(api.ListOptions{PageSize: 3})
+	var names []string
+	for it.Next(ctx) {
+		names = append(names, it.Item().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("IterTokens() failed: %v", err)
+	}
+	want := []string{"token-0", "token-1", "token-2", "token-3", "token-4", "token-5", "token-6"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("IterTokens() = %v, want %v", names, want)
+	}
+
+	// A server that caps the page size and pages by offset only
+	s.MaxPageSize = 2
+	s.OffsetPaging = true
+	names = nil
+	it = client.IterTokens(api.ListOptions{PageSize: 5})
+	for it.Next(ctx) {
+		names = append(names, it.Item().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("IterTokens() with capped pages failed: %v", err)
+	}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("IterTokens() with capped pages = %v, want %v", names, want)
+	}
+	s.MaxPageSize, s.OffsetPaging = 0, false
+
+	_, err = client.ListTokensPage(ctx, api.PageRequest{Cursor: "not-a-cursor"})
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid cursor: error = %v, want 400", err)
+	}
+}
@@ -1,6 +1,7 @@
 #!/bin/bash
 # Chrono CLI Test Script
 # Tests the CLI without requiring authentication
+# Without a platform, start one with: ./chrono dev-server --auto-approve
 
 CHRONO_BIN="${CHRONO_BIN:-./chrono}"
 API_URL="${API_URL:-http://localhost:8080/api/v1}"